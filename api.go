@@ -0,0 +1,319 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// apiIssue is the JSON form of an issue, shared by the MCP and HTTP servers
+type apiIssue struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Status      string    `json:"status"`
+	Ready       bool      `json:"ready"`
+	Labels      []string  `json:"labels"`
+	Owner       string    `json:"owner,omitempty"`
+	Branch      string    `json:"branch,omitempty"`
+	ExternalRef string    `json:"external_ref,omitempty"`
+	DependsOn   []string  `json:"depends_on"`
+	Blocks      []string  `json:"blocks"`
+	Comments    []string  `json:"comments"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Resolution  string    `json:"resolution,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	DuplicateOf string    `json:"duplicate_of,omitempty"`
+	ClosedAt    time.Time `json:"closed_at,omitzero"`
+}
+
+func toAPIIssue(store *Store, issue *Issue) apiIssue {
+	nonNil := func(items []string) []string {
+		if items == nil {
+			return []string{}
+		}
+		return items
+	}
+	return apiIssue{
+		ID:          issue.ID,
+		Title:       issue.Title,
+		Status:      issue.Status,
+		Ready:       issue.Status == "open" && store.IsReady(issue),
+		Labels:      nonNil(issue.Labels),
+		Owner:       issue.Owner,
+		Branch:      issue.Branch,
+		ExternalRef: issue.ExternalRef,
+		DependsOn:   nonNil(issue.DependsOn),
+		Blocks:      nonNil(issue.Blocks),
+		Comments:    nonNil(issue.Comments),
+		CreatedAt:   issue.CreatedAt,
+		UpdatedAt:   issue.UpdatedAt,
+		Resolution:  issue.Resolution,
+		Reason:      issue.Reason,
+		DuplicateOf: issue.DuplicateOf,
+		ClosedAt:    issue.ClosedAt,
+	}
+}
+
+type apiIssueResult struct {
+	Issue *apiIssue `json:"issue"`
+}
+
+type apiIssuesResult struct {
+	Issues []apiIssue `json:"issues"`
+}
+
+func issueResult(store *Store, issue *Issue) apiIssueResult {
+	view := toAPIIssue(store, issue)
+	return apiIssueResult{Issue: &view}
+}
+
+func issuesResult(store *Store, issues []*Issue) apiIssuesResult {
+	views := make([]apiIssue, 0, len(issues))
+	for _, issue := range issues {
+		views = append(views, toAPIIssue(store, issue))
+	}
+	return apiIssuesResult{Issues: views}
+}
+
+// apiCloseResult is the result of closing or deleting an issue, with the
+// issues that became ready because of it
+type apiCloseResult struct {
+	Issue      *apiIssue  `json:"issue,omitempty"`
+	AlsoClosed []apiIssue `json:"also_closed,omitempty"`
+	NowReady   []apiIssue `json:"now_ready"`
+}
+
+// closeResult describes closed, the issues from CloseIssues (none for a
+// delete), and the issues that became ready
+func closeResult(store *Store, closed []*Issue, nowReady []*Issue) apiCloseResult {
+	result := apiCloseResult{NowReady: issuesResult(store, nowReady).Issues}
+	if len(closed) > 0 {
+		result.Issue = issueResult(store, closed[0]).Issue
+	}
+	if len(closed) > 1 {
+		result.AlsoClosed = issuesResult(store, closed[1:]).Issues
+	}
+	return result
+}
+
+// readyQueue returns the ready issues, oldest first, the order they should
+// be worked on
+func readyQueue(store *Store) []*Issue {
+	ready, _, _ := partitionIssues(store)
+	slices.Reverse(ready)
+	return ready
+}
+
+// resolveIDs resolves partial IDs, naming the argument they came from in
+// errors
+func resolveIDs(store *Store, field string, refs []string) ([]string, error) {
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		id, err := store.ResolveIssueID(ref)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// checkNoCycle rejects changes that made an issue depend on itself
+func checkNoCycle(store *Store) error {
+	if cycle := findCycle(store.dependencyGraph()); cycle != nil {
+		return fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+	}
+	return nil
+}
+
+// claimIssue records owner on issue, keeping its branch
+func claimIssue(store *Store, issue *Issue, owner string, force bool) error {
+	owner = strings.TrimSpace(owner)
+	if owner == "" {
+		return fmt.Errorf("owner is required")
+	}
+	if issue.Owner != "" && issue.Owner != owner && !force {
+		return fmt.Errorf("issue %s is claimed by %s (set force to take it over)", issue.ID, issue.Owner)
+	}
+	return store.StartIssue(issue.ID, owner, issue.Branch)
+}
+
+type createParams struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels"`
+	DependsOn   []string `json:"depends_on"`
+	Blocks      []string `json:"blocks"`
+}
+
+// createIssue adds an issue with its relationships, which must not form a
+// cycle
+func createIssue(store *Store, in createParams) (*Issue, error) {
+	dependsOn, err := resolveIDs(store, "depends_on", in.DependsOn)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := resolveIDs(store, "blocks", in.Blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	issue, _, err := store.CreateFromTemplate(&Template{Title: in.Title, Description: in.Description, Labels: in.Labels})
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range dependsOn {
+		if err := store.AddDependency(issue.ID, id); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range blocks {
+		if err := store.AddBlocker(issue.ID, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkNoCycle(store); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+type listParams struct {
+	Status string `json:"status"`
+	Label  string `json:"label"`
+	Owner  string `json:"owner"`
+	Limit  int    `json:"limit"`
+}
+
+// filterIssues returns the issues matching in: ready first, then blocked,
+// then closed, newest first within each
+func filterIssues(store *Store, in listParams) ([]*Issue, error) {
+	ready, blocked, closed := partitionIssues(store)
+	var issues []*Issue
+	switch in.Status {
+	case "", "open":
+		issues = append(ready, blocked...)
+	case "ready":
+		issues = ready
+	case "blocked":
+		issues = blocked
+	case "closed":
+		issues = closed
+	case "all":
+		issues = append(append(ready, blocked...), closed...)
+	default:
+		return nil, fmt.Errorf("invalid status %q (must be open, ready, blocked, closed, or all)", in.Status)
+	}
+
+	issues = slices.DeleteFunc(issues, func(issue *Issue) bool {
+		return (in.Label != "" && !slices.Contains(issue.Labels, in.Label)) ||
+			(in.Owner != "" && issue.Owner != in.Owner)
+	})
+	if in.Limit > 0 && len(issues) > in.Limit {
+		issues = issues[:in.Limit]
+	}
+	return issues, nil
+}
+
+type updateParams struct {
+	Title           string   `json:"title"`
+	Comment         string   `json:"comment"`
+	AddDependsOn    []string `json:"add_depends_on"`
+	RemoveDependsOn []string `json:"remove_depends_on"`
+	AddBlocks       []string `json:"add_blocks"`
+	RemoveBlocks    []string `json:"remove_blocks"`
+	AddLabels       []string `json:"add_labels"`
+	RemoveLabels    []string `json:"remove_labels"`
+}
+
+// updateIssue applies in to issue. Adding an existing relationship or
+// removing a missing one is a no-op rather than a duplicate or an error.
+func updateIssue(store *Store, issue *Issue, in updateParams) error {
+	if title := strings.TrimSpace(in.Title); title != "" {
+		if err := store.UpdateIssueTitle(issue.ID, title); err != nil {
+			return err
+		}
+	}
+
+	relationships := []struct {
+		field   string
+		refs    []string
+		current []string
+		add     bool
+		apply   func(issueID, otherID string) error
+	}{
+		{"add_depends_on", in.AddDependsOn, issue.DependsOn, true, store.AddDependency},
+		{"remove_depends_on", in.RemoveDependsOn, issue.DependsOn, false, store.RemoveDependency},
+		{"add_blocks", in.AddBlocks, issue.Blocks, true, store.AddBlocker},
+		{"remove_blocks", in.RemoveBlocks, issue.Blocks, false, store.RemoveBlocker},
+	}
+	for _, rel := range relationships {
+		ids, err := resolveIDs(store, rel.field, rel.refs)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if slices.Contains(rel.current, id) == rel.add {
+				continue
+			}
+			if err := rel.apply(issue.ID, id); err != nil {
+				return err
+			}
+		}
+	}
+	if err := checkNoCycle(store); err != nil {
+		return err
+	}
+
+	labels := slices.Clone(issue.Labels)
+	for _, label := range in.AddLabels {
+		if label = strings.TrimSpace(label); label != "" && !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	labels = slices.DeleteFunc(labels, func(label string) bool { return slices.Contains(in.RemoveLabels, label) })
+	if !slices.Equal(labels, issue.Labels) {
+		issue.Labels = labels
+		store.touch(issue)
+	}
+
+	if in.Comment != "" {
+		return store.AddComment(issue.ID, in.Comment)
+	}
+	return nil
+}
+
+type searchParams struct {
+	Query         string `json:"query"`
+	IncludeClosed bool   `json:"include_closed"`
+	Limit         int    `json:"limit"`
+}
+
+// searchIssues returns the issues whose ID, title, labels, or comments
+// contain every word of the query, ignoring case
+func searchIssues(store *Store, in searchParams) ([]*Issue, error) {
+	terms := strings.Fields(strings.ToLower(in.Query))
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("query is required")
+	}
+
+	ready, blocked, closed := partitionIssues(store)
+	candidates := append(ready, blocked...)
+	if in.IncludeClosed {
+		candidates = append(candidates, closed...)
+	}
+	matches := slices.DeleteFunc(candidates, func(issue *Issue) bool { return !issueMatches(issue, terms) })
+	if in.Limit > 0 && len(matches) > in.Limit {
+		matches = matches[:in.Limit]
+	}
+	return matches, nil
+}
+
+// issueMatches reports whether every lowercase term appears in the issue's
+// ID, title, labels, or comments
+func issueMatches(issue *Issue, terms []string) bool {
+	text := strings.ToLower(strings.Join(append([]string{issue.ID, issue.Title, strings.Join(issue.Labels, " ")}, issue.Comments...), "\n"))
+	return !slices.ContainsFunc(terms, func(term string) bool { return !strings.Contains(text, term) })
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// beadsIssue is a single record in a Beads issues.jsonl file
+type beadsIssue struct {
+	ID                 string            `json:"id"`
+	Title              string            `json:"title"`
+	Description        string            `json:"description,omitempty"`
+	Design             string            `json:"design,omitempty"`
+	AcceptanceCriteria string            `json:"acceptance_criteria,omitempty"`
+	Notes              string            `json:"notes,omitempty"`
+	Status             string            `json:"status"`
+	Priority           int               `json:"priority"`
+	IssueType          string            `json:"issue_type,omitempty"`
+	Labels             []string          `json:"labels,omitempty"`
+	ExternalRef        string            `json:"external_ref,omitempty"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+	ClosedAt           *time.Time        `json:"closed_at,omitempty"`
+	Dependencies       []beadsDependency `json:"dependencies,omitempty"`
+	Comments           []beadsComment    `json:"comments,omitempty"`
+}
+
+// beadsDependency is a dependency edge: IssueID depends on DependsOnID
+type beadsDependency struct {
+	IssueID     string    `json:"issue_id"`
+	DependsOnID string    `json:"depends_on_id"`
+	Type        string    `json:"type"`
+	CreatedAt   time.Time `json:"created_at,omitzero"`
+}
+
+// beadsComment is a comment on a Beads issue
+type beadsComment struct {
+	IssueID   string    `json:"issue_id"`
+	Author    string    `json:"author,omitempty"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
+}
+
+const (
+	beadsRefPrefix       = "beads:"
+	beadsDefaultPriority = 2
+)
+
+// ImportBeads imports issues from a Beads JSONL stream into the store
+// Issues already imported (matched by external reference, or by ID for
+// issues that were exported from this store) are updated in place
+func ImportBeads(store *Store, r io.Reader) (*importResult, error) {
+	var records []beadsIssue
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var record beadsIssue
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("line %d: invalid Beads record: %w", lineNum, err)
+		}
+		if record.ID == "" || record.Title == "" {
+			return nil, fmt.Errorf("line %d: Beads record is missing an id or title", lineNum)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &importResult{}
+	imported := make(map[string]*Issue, len(records))
+	changed := make(map[*Issue]bool)
+	created := make(map[*Issue]bool)
+
+	for _, record := range records {
+		issue := store.FindIssueByExternalRef(beadsRefPrefix + record.ID)
+		if issue == nil {
+			issue = store.Issues[record.ID]
+		}
+		if issue == nil {
+			var err error
+			issue, err = store.AddIssue(record.Title)
+			if err != nil {
+				return nil, err
+			}
+			issue.ExternalRef = beadsRefPrefix + record.ID
+			if !record.CreatedAt.IsZero() {
+				issue.CreatedAt = record.CreatedAt
+			}
+			created[issue] = true
+		}
+		imported[record.ID] = issue
+
+		comments := make([]string, 0, len(record.Comments)+4)
+		sections := []struct{ label, text string }{
+			{"", record.Description},
+			{"Design", record.Design},
+			{"Acceptance criteria", record.AcceptanceCriteria},
+			{"Notes", record.Notes},
+		}
+		for _, section := range sections {
+			if text := strings.TrimSpace(section.text); text != "" {
+				comments = append(comments, attributedComment(section.label, text))
+			}
+		}
+		if record.ExternalRef != "" {
+			comments = append(comments, attributedComment("External reference", record.ExternalRef))
+		}
+		for _, comment := range record.Comments {
+			comments = append(comments, attributedComment(comment.Author, comment.Text))
+		}
+
+		updated, err := updateImportedIssue(store, issue, record.Title, record.Labels, comments, record.Status == "closed")
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			changed[issue] = true
+		}
+	}
+
+	// Link dependencies once every record in the file exists
+	skipped := 0
+	for _, record := range records {
+		issue := imported[record.ID]
+		for _, dep := range record.Dependencies {
+			if dep.Type != "" && dep.Type != "blocks" {
+				skipped++
+				continue
+			}
+			blocker := imported[dep.DependsOnID]
+			if blocker == nil {
+				blocker = store.FindIssueByExternalRef(beadsRefPrefix + dep.DependsOnID)
+			}
+			if blocker == nil {
+				blocker = store.Issues[dep.DependsOnID]
+			}
+			if blocker == nil || blocker == issue || slices.Contains(issue.DependsOn, blocker.ID) {
+				continue
+			}
+			if err := store.AddDependency(issue.ID, blocker.ID); err != nil {
+				return nil, err
+			}
+			changed[issue] = true
+			changed[blocker] = true
+		}
+	}
+	if skipped > 0 {
+		result.Notes = append(result.Notes, fmt.Sprintf("Skipped %d non-blocking dependencies (mint only tracks blocking dependencies)", skipped))
+	}
+
+	for _, record := range records {
+		issue := imported[record.ID]
+		switch {
+		case created[issue]:
+			if !record.UpdatedAt.IsZero() {
+				issue.UpdatedAt = record.UpdatedAt
+			}
+			result.Created = append(result.Created, issue)
+		case changed[issue]:
+			result.Updated = append(result.Updated, issue)
+		default:
+			result.Unchanged++
+		}
+	}
+
+	return result, nil
+}
+
+// ExportBeads writes every issue in the store as a Beads JSONL record
+// Issues that were imported from Beads keep their original Beads IDs
+func ExportBeads(store *Store, w io.Writer) error {
+	beadsID := func(id string) string {
+		if issue := store.Issues[id]; issue != nil && strings.HasPrefix(issue.ExternalRef, beadsRefPrefix) {
+			return strings.TrimPrefix(issue.ExternalRef, beadsRefPrefix)
+		}
+		return id
+	}
+
+	enc := json.NewEncoder(w)
+	for _, issue := range store.ListIssues() {
+		id := beadsID(issue.ID)
+		record := beadsIssue{
+			ID:        id,
+			Title:     issue.Title,
+			Status:    issue.Status,
+			Priority:  beadsDefaultPriority,
+			IssueType: "task",
+			Labels:    issue.Labels,
+			CreatedAt: issue.CreatedAt,
+			UpdatedAt: issue.UpdatedAt,
+		}
+		if !strings.HasPrefix(issue.ExternalRef, beadsRefPrefix) {
+			record.ExternalRef = issue.ExternalRef
+		}
+		if issue.Status == "closed" {
+			closedAt := issue.ClosedAt
+			if closedAt.IsZero() {
+				closedAt = issue.UpdatedAt
+			}
+			record.ClosedAt = &closedAt
+		}
+
+		// The first comment is the description, mirroring create --description
+		comments := issue.Comments
+		if len(comments) > 0 {
+			record.Description = comments[0]
+			comments = comments[1:]
+		}
+		for _, comment := range comments {
+			record.Comments = append(record.Comments, beadsComment{IssueID: id, Text: comment})
+		}
+
+		for _, depID := range issue.DependsOn {
+			record.Dependencies = append(record.Dependencies, beadsDependency{
+				IssueID:     id,
+				DependsOnID: beadsID(depID),
+				Type:        "blocks",
+			})
+		}
+
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
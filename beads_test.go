@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const beadsExport = `{"id":"bd-1","title":"Set up CI","description":"Use GitHub Actions","design":"One workflow per OS","status":"closed","priority":1,"issue_type":"task","labels":["infra"],"created_at":"2024-01-02T10:00:00Z","updated_at":"2024-01-05T10:00:00Z","closed_at":"2024-01-05T10:00:00Z","comments":[{"issue_id":"bd-1","author":"alice","text":"Done","created_at":"2024-01-05T10:00:00Z"}]}
+{"id":"bd-2","title":"Write tests","status":"in_progress","priority":2,"created_at":"2024-01-03T10:00:00Z","updated_at":"2024-01-03T10:00:00Z","dependencies":[{"issue_id":"bd-2","depends_on_id":"bd-1","type":"blocks"},{"issue_id":"bd-2","depends_on_id":"bd-3","type":"blocks"},{"issue_id":"bd-2","depends_on_id":"bd-1","type":"discovered-from"}]}
+
+{"id":"bd-3","title":"Pick a framework","status":"open","priority":2,"created_at":"2024-01-04T10:00:00Z","updated_at":"2024-01-04T10:00:00Z"}
+`
+
+func TestImportBeads(t *testing.T) {
+	store := NewStore()
+
+	result, err := ImportBeads(store, strings.NewReader(beadsExport))
+	if err != nil {
+		t.Fatalf("ImportBeads() failed: %v", err)
+	}
+	if len(result.Created) != 3 {
+		t.Fatalf("expected 3 created issues, got %d", len(result.Created))
+	}
+	if len(result.Notes) != 1 || !strings.Contains(result.Notes[0], "Skipped 1 non-blocking") {
+		t.Errorf("expected a note about the skipped dependency, got %v", result.Notes)
+	}
+
+	ci := store.FindIssueByExternalRef("beads:bd-1")
+	tests := store.FindIssueByExternalRef("beads:bd-2")
+	framework := store.FindIssueByExternalRef("beads:bd-3")
+	if ci == nil || tests == nil || framework == nil {
+		t.Fatal("expected every record to be findable by its external reference")
+	}
+
+	if ci.Status != "closed" || tests.Status != "open" {
+		t.Errorf("unexpected statuses: %s, %s", ci.Status, tests.Status)
+	}
+	expectedComments := []string{"Use GitHub Actions", "Design: One workflow per OS", "alice: Done"}
+	if strings.Join(ci.Comments, "|") != strings.Join(expectedComments, "|") {
+		t.Errorf("expected comments %v, got %v", expectedComments, ci.Comments)
+	}
+	if ci.UpdatedAt.Day() != 5 {
+		t.Errorf("expected updated_at from Beads, got %v", ci.UpdatedAt)
+	}
+	if len(tests.DependsOn) != 2 || tests.DependsOn[0] != ci.ID || tests.DependsOn[1] != framework.ID {
+		t.Errorf("expected tests to depend on [%s %s], got %v", ci.ID, framework.ID, tests.DependsOn)
+	}
+
+	// Re-importing changes nothing
+	result, err = ImportBeads(store, strings.NewReader(beadsExport))
+	if err != nil {
+		t.Fatalf("second ImportBeads() failed: %v", err)
+	}
+	if len(result.Created) != 0 || len(result.Updated) != 0 || result.Unchanged != 3 {
+		t.Errorf("expected re-import to change nothing, got %d created, %d updated",
+			len(result.Created), len(result.Updated))
+	}
+	if len(store.Issues) != 3 {
+		t.Errorf("expected 3 issues, got %d", len(store.Issues))
+	}
+}
+
+func TestImportBeads_InvalidRecord(t *testing.T) {
+	store := NewStore()
+
+	if _, err := ImportBeads(store, strings.NewReader("{not json}\n")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+	if _, err := ImportBeads(store, strings.NewReader(`{"title":"No ID"}`+"\n")); err == nil {
+		t.Error("expected error for record without an ID")
+	}
+}
+
+func TestExportBeads(t *testing.T) {
+	store := NewStore()
+	a, _ := store.AddIssue("Blocker")
+	b, _ := store.AddIssue("Blocked")
+	_ = store.AddDependency(b.ID, a.ID)
+	_ = store.AddComment(a.ID, "The description")
+	_ = store.AddComment(a.ID, "A follow-up")
+	_ = store.CloseIssue(a.ID, "")
+	a.Labels = []string{"infra"}
+	b.ExternalRef = "https://github.com/acme/widgets/issues/2"
+
+	var buf bytes.Buffer
+	if err := ExportBeads(store, &buf); err != nil {
+		t.Fatalf("ExportBeads() failed: %v", err)
+	}
+
+	records := make(map[string]beadsIssue)
+	for line := range strings.SplitSeq(strings.TrimSpace(buf.String()), "\n") {
+		var record beadsIssue
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid JSONL line %q: %v", line, err)
+		}
+		records[record.ID] = record
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	blocker := records[a.ID]
+	if blocker.Status != "closed" || blocker.ClosedAt == nil {
+		t.Errorf("expected closed record with closed_at, got %+v", blocker)
+	}
+	if blocker.Description != "The description" {
+		t.Errorf("expected first comment as description, got '%s'", blocker.Description)
+	}
+	if len(blocker.Comments) != 1 || blocker.Comments[0].Text != "A follow-up" {
+		t.Errorf("expected remaining comments, got %+v", blocker.Comments)
+	}
+	if len(blocker.Labels) != 1 || blocker.Labels[0] != "infra" {
+		t.Errorf("expected labels [infra], got %v", blocker.Labels)
+	}
+
+	blocked := records[b.ID]
+	if len(blocked.Dependencies) != 1 || blocked.Dependencies[0].DependsOnID != a.ID || blocked.Dependencies[0].Type != "blocks" {
+		t.Errorf("expected a blocks dependency on %s, got %+v", a.ID, blocked.Dependencies)
+	}
+	if blocked.ExternalRef != b.ExternalRef {
+		t.Errorf("expected external_ref '%s', got '%s'", b.ExternalRef, blocked.ExternalRef)
+	}
+}
+
+func TestBeadsRoundTrip(t *testing.T) {
+	store := NewStore()
+	if _, err := ImportBeads(store, strings.NewReader(beadsExport)); err != nil {
+		t.Fatalf("ImportBeads() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := ExportBeads(store, &buf); err != nil {
+		t.Fatalf("ExportBeads() failed: %v", err)
+	}
+
+	// Issues imported from Beads keep their Beads IDs on the way out
+	output := buf.String()
+	for _, id := range []string{`"id":"bd-1"`, `"id":"bd-2"`, `"depends_on_id":"bd-3"`} {
+		if !strings.Contains(output, id) {
+			t.Errorf("expected export to contain %s, got: %s", id, output)
+		}
+	}
+
+	// Importing our own export back into the same store is a no-op
+	result, err := ImportBeads(store, strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	if len(result.Created) != 0 || len(result.Updated) != 0 {
+		t.Errorf("expected round trip to change nothing, got %d created, %d updated",
+			len(result.Created), len(result.Updated))
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// checklistDir is where checklists live, relative to the project root
+	checklistDir = ".mint/checklists"
+
+	// runLabelPrefix starts the label shared by the issues of a checklist run
+	runLabelPrefix = "run:"
+)
+
+// Checklist is a named sequence of steps, run as a chain of issues where
+// each step depends on the one before it. Steps can contain {{name}}
+// placeholders, like templates.
+type Checklist struct {
+	Labels []string          `yaml:"labels"`
+	Vars   map[string]string `yaml:"vars"` // defaults; empty means required
+	Steps  []ChecklistStep   `yaml:"steps"`
+}
+
+// ChecklistStep is one issue in a checklist run
+// A step can be written as just its title
+type ChecklistStep struct {
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description"`
+	Labels      []string `yaml:"labels"`
+}
+
+// UnmarshalYAML accepts a plain string as the step's title
+func (c *ChecklistStep) UnmarshalYAML(unmarshal func(any) error) error {
+	var title string
+	if err := unmarshal(&title); err == nil {
+		*c = ChecklistStep{Title: title}
+		return nil
+	}
+	type plain ChecklistStep
+	return unmarshal((*plain)(c))
+}
+
+// LoadChecklist reads the checklist called name from the project at root
+func LoadChecklist(root, name string) (*Checklist, error) {
+	var checklist Checklist
+	path, err := loadNamedYAML(root, checklistDir, "checklist", name, &checklist)
+	if err != nil {
+		return nil, err
+	}
+	if len(checklist.Steps) == 0 {
+		return nil, fmt.Errorf("%s: checklist has no steps", path)
+	}
+	for i, step := range checklist.Steps {
+		if strings.TrimSpace(step.Title) == "" {
+			return nil, fmt.Errorf("%s: step %d has no title", path, i+1)
+		}
+	}
+	return &checklist, nil
+}
+
+// Expand returns a copy of the checklist with its placeholders replaced by
+// vars, falling back to the checklist's defaults
+func (c *Checklist) Expand(vars map[string]string) (*Checklist, error) {
+	e := newTemplateExpander(c.Vars, vars)
+	out := &Checklist{Labels: e.expandAll(c.Labels)}
+	for _, step := range c.Steps {
+		out.Steps = append(out.Steps, ChecklistStep{
+			Title:       e.expand(step.Title),
+			Description: e.expand(step.Description),
+			Labels:      e.expandAll(step.Labels),
+		})
+	}
+	if err := e.err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// nextRunID returns the first unused run ID of the form <name>-<n>
+func (s *Store) nextRunID(name string) string {
+	highest := 0
+	for _, issue := range s.Issues {
+		for _, label := range issue.Labels {
+			suffix, ok := strings.CutPrefix(label, runLabelPrefix+name+"-")
+			if !ok {
+				continue
+			}
+			if n, err := strconv.Atoi(suffix); err == nil && n > highest {
+				highest = n
+			}
+		}
+	}
+	return fmt.Sprintf("%s-%d", name, highest+1)
+}
+
+// RunChecklist adds an issue for each step of an expanded checklist, each
+// depending on the previous step and labeled with the run ID.
+// Returns the issues in step order.
+func (s *Store) RunChecklist(checklist *Checklist, runID string) ([]*Issue, error) {
+	if strings.ContainsAny(runID, " \t,") || runID == "" {
+		return nil, fmt.Errorf("invalid run ID %q", runID)
+	}
+	if len(s.ChecklistRun(runID)) > 0 {
+		return nil, fmt.Errorf("checklist run %s already exists", runID)
+	}
+
+	issues := make([]*Issue, 0, len(checklist.Steps))
+	for i, step := range checklist.Steps {
+		issue, err := s.AddIssue(step.Title)
+		if err != nil {
+			return nil, err
+		}
+		issue.Labels = templateLabels(append(slices.Clone(checklist.Labels), step.Labels...), "")
+		issue.Labels = append(issue.Labels, runLabelPrefix+runID)
+		if step.Description != "" {
+			if err := s.AddComment(issue.ID, strings.TrimSpace(step.Description)); err != nil {
+				return nil, err
+			}
+		}
+		if i > 0 {
+			if err := s.AddDependency(issue.ID, issues[i-1].ID); err != nil {
+				return nil, err
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// ChecklistRun returns the issues labeled with runID, in dependency order
+// Issues that are equally far along the chain are ordered by creation time
+func (s *Store) ChecklistRun(runID string) []*Issue {
+	label := runLabelPrefix + strings.TrimPrefix(runID, runLabelPrefix)
+	var issues []*Issue
+	inRun := make(map[string]bool)
+	for _, issue := range s.Issues {
+		if slices.Contains(issue.Labels, label) {
+			issues = append(issues, issue)
+			inRun[issue.ID] = true
+		}
+	}
+	slices.SortFunc(issues, func(a, b *Issue) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	// Repeatedly take the earliest issue whose dependencies in the run have
+	// all been taken; anything left over is in a cycle and keeps its order
+	var ordered []*Issue
+	taken := make(map[string]bool)
+	for len(ordered) < len(issues) {
+		progressed := false
+		for _, issue := range issues {
+			if taken[issue.ID] {
+				continue
+			}
+			ready := true
+			for _, depID := range issue.DependsOn {
+				if inRun[depID] && !taken[depID] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, issue)
+				taken[issue.ID] = true
+				progressed = true
+				break
+			}
+		}
+		if !progressed {
+			for _, issue := range issues {
+				if !taken[issue.ID] {
+					ordered = append(ordered, issue)
+					taken[issue.ID] = true
+				}
+			}
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const releaseChecklist = `labels: [release]
+steps:
+  - Generate changelog for {{version}}
+  - title: Tag v{{version}}
+    description: git tag v{{version}}
+    labels: [git]
+  - Push
+`
+
+func writeChecklist(t *testing.T, root, name, content string) {
+	t.Helper()
+	dir := filepath.Join(root, checklistDir)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadChecklist(t *testing.T) {
+	root := t.TempDir()
+	writeChecklist(t, root, "release", releaseChecklist)
+
+	checklist, err := LoadChecklist(root, "release")
+	if err != nil {
+		t.Fatalf("LoadChecklist() failed: %v", err)
+	}
+	if len(checklist.Steps) != 3 {
+		t.Fatalf("expected 3 steps, got %d", len(checklist.Steps))
+	}
+	if checklist.Steps[0].Title != "Generate changelog for {{version}}" {
+		t.Errorf("expected a plain string step to become the title, got %+v", checklist.Steps[0])
+	}
+	if checklist.Steps[1].Description != "git tag v{{version}}" {
+		t.Errorf("expected step description, got %+v", checklist.Steps[1])
+	}
+
+	writeChecklist(t, root, "empty", "labels: [x]\n")
+	if _, err := LoadChecklist(root, "empty"); err == nil || !strings.Contains(err.Error(), "no steps") {
+		t.Errorf("expected no steps error, got %v", err)
+	}
+	writeChecklist(t, root, "typo", "step:\n  - one\n")
+	if _, err := LoadChecklist(root, "typo"); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestRunChecklist(t *testing.T) {
+	root := t.TempDir()
+	writeChecklist(t, root, "release", releaseChecklist)
+	checklist, _ := LoadChecklist(root, "release")
+	expanded, err := checklist.Expand(map[string]string{"version": "1.2"})
+	if err != nil {
+		t.Fatalf("Expand() failed: %v", err)
+	}
+
+	store := NewStore()
+	runID := store.nextRunID("release")
+	if runID != "release-1" {
+		t.Errorf("expected run ID release-1, got %s", runID)
+	}
+	issues, err := store.RunChecklist(expanded, runID)
+	if err != nil {
+		t.Fatalf("RunChecklist() failed: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d", len(issues))
+	}
+	if issues[1].Title != "Tag v1.2" || strings.Join(issues[1].Labels, ",") != "release,git,run:release-1" {
+		t.Errorf("unexpected step issue: %+v", issues[1])
+	}
+	if len(issues[0].DependsOn) != 0 || issues[1].DependsOn[0] != issues[0].ID || issues[2].DependsOn[0] != issues[1].ID {
+		t.Error("expected each step to depend on the previous one")
+	}
+	if !store.IsReady(issues[0]) || store.IsReady(issues[1]) {
+		t.Error("expected only the first step to be ready")
+	}
+
+	if next := store.nextRunID("release"); next != "release-2" {
+		t.Errorf("expected next run ID release-2, got %s", next)
+	}
+	if _, err := store.RunChecklist(expanded, runID); err == nil {
+		t.Error("expected error reusing a run ID")
+	}
+}
+
+func TestChecklistRunOrder(t *testing.T) {
+	store := NewStore()
+	checklist := &Checklist{Steps: []ChecklistStep{{Title: "one"}, {Title: "two"}, {Title: "three"}}}
+	issues, _ := store.RunChecklist(checklist, "r-1")
+
+	// Creation times that disagree with the chain don't change the order
+	now := time.Now()
+	for i, issue := range issues {
+		issue.CreatedAt = now.Add(-time.Duration(i) * time.Minute)
+	}
+
+	ordered := store.ChecklistRun("run:r-1")
+	var titles []string
+	for _, issue := range ordered {
+		titles = append(titles, issue.Title)
+	}
+	if strings.Join(titles, ",") != "one,two,three" {
+		t.Errorf("expected chain order, got %v", titles)
+	}
+}
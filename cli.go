@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/urfave/cli/v3"
 )
@@ -12,25 +13,36 @@ import (
 var version = "dev-?"
 
 func main() {
-	cmd := newCommand()
-	if err := cmd.Run(context.Background(), os.Args); err != nil {
+	if err := runCommand(context.Background(), newCommand(), os.Args); err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
 	}
 }
 
+// runCommand runs cmd with args, expanding a custom command from the config
+func runCommand(ctx context.Context, cmd *cli.Command, args []string) error {
+	if cfg, err := LoadConfig(); err == nil {
+		if args, err = expandCommand(cmd, args, cfg.Commands()); err != nil {
+			return err
+		}
+	}
+	return cmd.Run(ctx, args)
+}
+
 func newCommand() *cli.Command {
 	return &cli.Command{
-		Name:                  "mint",
-		Usage:                 "A simple command line tool to create and track work.",
-		Version:               version,
-		EnableShellCompletion: true,
+		Name:                            "mint",
+		Usage:                           "A simple command line tool to create and track work.",
+		Version:                         version,
+		EnableShellCompletion:           true,
+		ConfigureShellCompletionCommand: configureCompletionCommand,
+		Before:                          applyConfig,
 		Commands: []*cli.Command{
 			{
 				Name:      "create",
 				Aliases:   []string{"add", "new", "c", "a", "n"},
 				Usage:     "Create a new issue",
-				ArgsUsage: "<title>",
+				ArgsUsage: "[title]",
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:  "description",
@@ -50,8 +62,22 @@ func newCommand() *cli.Command {
 						Aliases: []string{"b"},
 						Usage:   "Add blocked issues (can be repeated)",
 					},
+					&cli.StringFlag{
+						Name:    "template",
+						Aliases: []string{"t"},
+						Usage:   "Create from .mint/templates/<name>.yaml (the title argument is then optional)",
+					},
+					&cli.StringSliceFlag{
+						Name:  "var",
+						Usage: "Set a template variable as name=value (can be repeated)",
+					},
+					&cli.BoolFlag{
+						Name:  "no-dup-check",
+						Usage: "Don't warn about similar open issues",
+					},
 				},
-				Action: createAction,
+				Action:        createAction,
+				ShellComplete: completeIssues(nil, map[string]issueFilter{"depends-on": openIssue, "blocks": openIssue}),
 			},
 			{
 				Name:    "list",
@@ -70,6 +96,14 @@ func newCommand() *cli.Command {
 						Name:  "limit",
 						Usage: "Limit the number of issues shown per section",
 					},
+					&cli.StringFlag{
+						Name:  "sort",
+						Usage: "Sort order: default, created, updated, id, or title",
+					},
+					&cli.BoolFlag{
+						Name:  "watch",
+						Usage: "Redraw whenever the issues file changes, marking changed issues",
+					},
 				},
 				Action: listAction,
 			},
@@ -78,7 +112,14 @@ func newCommand() *cli.Command {
 				Aliases:   []string{"s"},
 				Usage:     "Show an issue and its details",
 				ArgsUsage: "<issue-id>",
-				Action:    showAction,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "watch",
+						Usage: "Redraw whenever the issues file changes, marking changed issues",
+					},
+				},
+				Action:        showAction,
+				ShellComplete: completeIssues(anyIssue, nil),
 			},
 			{
 				Name:      "update",
@@ -118,6 +159,21 @@ func newCommand() *cli.Command {
 					},
 				},
 				Action: updateAction,
+				ShellComplete: completeIssues(anyIssue, map[string]issueFilter{
+					"depends-on":        openIssue,
+					"blocks":            openIssue,
+					"remove-depends-on": anyIssue,
+					"remove-blocks":     anyIssue,
+				}),
+			},
+			{
+				Name:          "edit",
+				Aliases:       []string{"e"},
+				Usage:         "Edit an issue in $EDITOR",
+				ArgsUsage:     "<issue-id>",
+				Description:   "Opens the issue as Markdown with YAML front matter. Invalid changes reopen the editor with the error.",
+				Action:        editAction,
+				ShellComplete: completeIssues(anyIssue, nil),
 			},
 			{
 				Name:      "close",
@@ -129,22 +185,95 @@ func newCommand() *cli.Command {
 						Name:  "reason",
 						Usage: "Reason for closing",
 					},
+					&cli.StringFlag{
+						Name:  "as",
+						Value: resolutionDone,
+						Usage: "Resolution: " + strings.Join(resolutions, ", "),
+					},
+					&cli.StringFlag{
+						Name:  "of",
+						Usage: "The issue this one duplicates, with --as duplicate",
+					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Close even if the issue depends on open issues",
+					},
+					&cli.BoolFlag{
+						Name:  "cascade",
+						Usage: "Also close every open issue that depends on this one",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print the closed issues and the issues now ready as JSON",
+					},
 				},
-				Action: closeAction,
+				Action:        closeAction,
+				ShellComplete: completeIssues(openIssue, map[string]issueFilter{"of": anyIssue}),
 			},
 			{
-				Name:      "open",
-				Aliases:   []string{"o"},
-				Usage:     "Re-open a closed issue",
-				ArgsUsage: "<issue-id>",
-				Action:    openAction,
+				Name:          "open",
+				Aliases:       []string{"o"},
+				Usage:         "Re-open a closed issue",
+				ArgsUsage:     "<issue-id>",
+				Action:        openAction,
+				ShellComplete: completeIssues(closedIssue, nil),
 			},
 			{
 				Name:      "delete",
 				Aliases:   []string{"d"},
 				Usage:     "Delete an issue",
 				ArgsUsage: "<issue-id>",
-				Action:    deleteAction,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "cascade",
+						Usage: "Make issues depending on this one depend on its dependencies instead",
+					},
+				},
+				Action:        deleteAction,
+				ShellComplete: completeIssues(anyIssue, nil),
+			},
+			{
+				Name:   "dups",
+				Usage:  "List groups of open issues that are likely duplicates",
+				Action: dupsAction,
+			},
+			{
+				Name:  "alias",
+				Usage: "Manage other names that resolve to issues",
+				Description: "Aliases work anywhere an issue ID does. IDs changed by set-prefix and the IDs\n" +
+					"of merged duplicates are kept as aliases automatically.",
+				Commands: []*cli.Command{
+					{
+						Name:      "add",
+						Usage:     "Add an alias for an issue",
+						ArgsUsage: "<alias> <issue-id>",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "force",
+								Usage: "Replace an alias that refers to another issue",
+							},
+						},
+						Action: aliasAddAction,
+					},
+					{
+						Name:   "list",
+						Usage:  "List aliases and the issues they refer to",
+						Action: aliasListAction,
+					},
+					{
+						Name:      "remove",
+						Usage:     "Remove an alias",
+						ArgsUsage: "<alias>",
+						Action:    aliasRemoveAction,
+					},
+				},
+			},
+			{
+				Name:          "merge",
+				Usage:         "Merge a duplicate issue into another, leaving its ID as an alias",
+				ArgsUsage:     "<keep-id> <duplicate-id>",
+				Action:        mergeAction,
+				ShellComplete: completeIssueArgs(2, anyIssue, nil),
 			},
 			{
 				Name:      "set-prefix",
@@ -152,6 +281,260 @@ func newCommand() *cli.Command {
 				ArgsUsage: "<new-prefix>",
 				Action:    setPrefixAction,
 			},
+			{
+				Name:          "start",
+				Usage:         "Claim an issue and check out a git branch for it",
+				ArgsUsage:     "<issue-id>",
+				Action:        startAction,
+				ShellComplete: completeIssues(openIssue, nil),
+			},
+			{
+				Name:   "status",
+				Usage:  "Show the issue for the current git branch",
+				Action: statusAction,
+			},
+			{
+				Name:   "where",
+				Usage:  "Show which store file is used and why",
+				Action: whereAction,
+			},
+			{
+				Name:  "config",
+				Usage: "Get and set configuration",
+				Description: "Settings are read from ~/.config/mint/config.yaml, then .mint.yaml at the\n" +
+					"project root, then MINT_<KEY> environment variables (e.g. MINT_LIST_LIMIT),\n" +
+					"each overriding the last. Command-line flags override all of them.\n" +
+					"Custom commands are set as commands.<name>, e.g. `mint config set commands.r \"list --ready\"`.",
+				Commands: []*cli.Command{
+					{
+						Name:      "get",
+						Usage:     "Print the effective value of a setting",
+						ArgsUsage: "<key>",
+						Action:    configGetAction,
+					},
+					{
+						Name:      "set",
+						Usage:     "Set a value in the repo config, or remove it if empty",
+						ArgsUsage: "<key> <value>",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "user",
+								Usage: "Write to the user config instead",
+							},
+						},
+						Action: configSetAction,
+					},
+					{
+						Name:   "list",
+						Usage:  "List every setting with its value and source",
+						Action: configListAction,
+					},
+				},
+			},
+			{
+				Name:   "tui",
+				Usage:  "Browse and triage issues in a full-screen terminal UI",
+				Action: tuiAction,
+			},
+			{
+				Name:  "batch",
+				Usage: "Apply a script of commands from stdin in a single transaction",
+				Description: "Reads one command per line (e.g. `create \"Title\" --depends-on $1 --as setup`)\n" +
+					"or one JSON operation per line (e.g. `{\"op\":\"close\",\"id\":\"@setup\"}`).\n" +
+					"$N refers to the issue touched by the Nth operation and @name to an issue\n" +
+					"named with --as. Changes are saved only if every operation succeeds.",
+				Action: batchAction,
+			},
+			{
+				Name:  "import",
+				Usage: "Import issues from other tools",
+				Commands: []*cli.Command{
+					{
+						Name:        "github",
+						Usage:       "Import issues from a `gh issue list --json ...` export",
+						ArgsUsage:   "<file.json>",
+						Description: "Export with: gh issue list --state all --json number,title,body,state,url,labels,comments,createdAt,updatedAt",
+						Action:      importGitHubAction,
+					},
+					{
+						Name:      "beads",
+						Usage:     "Import issues from a Beads JSONL file (defaults to .beads/issues.jsonl)",
+						ArgsUsage: "[file.jsonl]",
+						Action:    importBeadsAction,
+					},
+					{
+						Name:      "csv",
+						Usage:     "Create or update issues from a CSV file, matching rows by id",
+						ArgsUsage: "<file.csv>",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "dry-run",
+								Usage: "Print the changes without saving them",
+							},
+							&cli.BoolFlag{
+								Name:  "force",
+								Usage: "Close issues even if they depend on open issues",
+							},
+						},
+						Action: importCSVAction,
+					},
+				},
+			},
+			{
+				Name:      "scan",
+				Usage:     "Create issues from TODO, FIXME, and HACK comments",
+				ArgsUsage: "[paths...]",
+				Description: "Finds markers in source files (respecting .gitignore), creates an issue for each\n" +
+					"new one, and closes issues whose marker has been removed.",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "write-ids",
+						Usage: "Write issue IDs back into markers, e.g. TODO(mint-ab3)",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show what would change without saving",
+					},
+				},
+				Action: scanAction,
+			},
+			{
+				Name:  "checklist",
+				Usage: "Run checklists as chains of issues",
+				Description: "Checklists live in .mint/checklists/<name>.yaml as a list of steps. Each run\n" +
+					"creates an issue per step, each depending on the one before, all labeled run:<id>.",
+				Commands: []*cli.Command{
+					{
+						Name:      "run",
+						Usage:     "Create the issues for a new run of a checklist",
+						ArgsUsage: "<name>",
+						Flags: []cli.Flag{
+							&cli.StringSliceFlag{
+								Name:  "var",
+								Usage: "Set a checklist variable as name=value (can be repeated)",
+							},
+							&cli.StringFlag{
+								Name:  "run",
+								Usage: "ID for the run (defaults to <name>-<n>)",
+							},
+						},
+						Action: checklistRunAction,
+					},
+					{
+						Name:      "status",
+						Usage:     "Show progress through a run, or every run",
+						ArgsUsage: "[run-id]",
+						Action:    checklistStatusAction,
+					},
+				},
+			},
+			{
+				Name:  "mcp",
+				Usage: "Serve the store to agents over the Model Context Protocol on stdio",
+				Description: "Tools: create, list, show, update, close, reopen, claim, next, search.\n" +
+					"Resources: mint://ready (the ready queue) and mint://issues/<id>.",
+				Action: mcpAction,
+			},
+			{
+				Name:  "serve",
+				Usage: "Serve the store as a JSON API over HTTP",
+				Description: "Routes: GET and POST /issues, GET, PATCH, and DELETE /issues/<id>,\n" +
+					"GET and POST /issues/<id>/comments, POST /issues/<id>/depends_on and\n" +
+					"/issues/<id>/blocks with {\"id\": ...}, DELETE /issues/<id>/depends_on/<other>\n" +
+					"and /issues/<id>/blocks/<other>, and GET /ready.",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "addr",
+						Value: "127.0.0.1:7777",
+						Usage: "Address to listen on",
+					},
+				},
+				Action: serveAction,
+			},
+			{
+				Name:  "sync-commits",
+				Usage: "Close or comment on issues referenced in git commit messages",
+				Description: "Reads the local git log for keywords followed by issue IDs. \"Closes\",\n" +
+					"\"Fixes\", and \"Resolves\" close the issue; \"Refs\" and \"See\" add a comment.\n" +
+					"Each commit is applied to an issue at most once.",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only read commits after this revision",
+					},
+				},
+				Action: syncCommitsAction,
+			},
+			{
+				Name:  "install-hooks",
+				Usage: "Install a git post-commit hook that runs sync-commits",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Replace an existing post-commit hook",
+					},
+				},
+				Action: installHooksAction,
+			},
+			{
+				Name:  "export",
+				Usage: "Export issues for other tools",
+				Commands: []*cli.Command{
+					{
+						Name:  "beads",
+						Usage: "Export issues as Beads JSONL",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:    "out",
+								Aliases: []string{"o"},
+								Usage:   "Write to a file instead of stdout",
+							},
+						},
+						Action: exportBeadsAction,
+					},
+					{
+						Name:  "markdown",
+						Usage: "Export issues as a Markdown document",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:    "out",
+								Aliases: []string{"o"},
+								Usage:   "Write to a file instead of stdout",
+							},
+						},
+						Action: exportMarkdownAction,
+					},
+					{
+						Name:  "html",
+						Usage: "Export issues as a static HTML site",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:    "out",
+								Aliases: []string{"o"},
+								Usage:   "Directory to write the site to",
+							},
+						},
+						Action: exportHTMLAction,
+					},
+					{
+						Name:  "csv",
+						Usage: "Export issues as CSV",
+						Flags: []cli.Flag{
+							&cli.StringSliceFlag{
+								Name:    "columns",
+								Aliases: []string{"c"},
+								Usage:   "Columns to include, comma separated (default: " + strings.Join(csvColumnNames(), ",") + ")",
+							},
+							&cli.StringFlag{
+								Name:    "out",
+								Aliases: []string{"o"},
+								Usage:   "Write to a file instead of stdout",
+							},
+						},
+						Action: exportCSVAction,
+					},
+				},
+			},
 		},
 	}
 }
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+func aliasAddAction(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() < 2 {
+		return fmt.Errorf("alias and issue ID are required")
+	}
+	alias, id := cmd.Args().Get(0), cmd.Args().Get(1)
+
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+	store.aliasNotes = cmd.Root().ErrWriter
+
+	issue, err := store.AddAlias(alias, id, cmd.Bool("force"))
+	if err != nil {
+		return err
+	}
+
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(cmd.Root().Writer, "\x1b[1;32m✔︎ %s now refers to %s\x1b[0m\n", alias, issue.ID)
+	return err
+}
+
+func aliasRemoveAction(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("alias is required")
+	}
+	alias := cmd.Args().First()
+
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err := store.RemoveAlias(alias); err != nil {
+		return err
+	}
+
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(cmd.Root().Writer, "Removed alias %s\n", alias)
+	return err
+}
+
+func aliasListAction(_ context.Context, cmd *cli.Command) error {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+	if len(store.Aliases) == 0 {
+		_, err := fmt.Fprintln(w, "No aliases")
+		return err
+	}
+
+	aliases := make([]string, 0, len(store.Aliases))
+	maxLen := 0
+	for alias := range store.Aliases {
+		aliases = append(aliases, alias)
+		maxLen = max(maxLen, len(alias))
+	}
+	sort.Strings(aliases)
+
+	for _, alias := range aliases {
+		padding := strings.Repeat(" ", 1+maxLen-len(alias))
+		issue := store.Issues[store.Aliases[alias]]
+		if issue == nil {
+			if _, err := fmt.Fprintf(w, "   %s%s%s (not found)\n", alias, padding, store.Aliases[alias]); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "   %s%s%s %s %s\n", alias, padding, store.FormatID(issue.ID), statusLabel(issue), issue.Title); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAliasCommands(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	issue, _ := store.AddIssue("Write parser")
+	_ = store.Save(filePath)
+
+	output, err := runMint(t, "alias", "list")
+	if err != nil {
+		t.Fatalf("alias list failed: %v", err)
+	}
+	if !strings.Contains(output, "No aliases") {
+		t.Errorf("expected no aliases, got: %s", output)
+	}
+
+	output, err = runMint(t, "alias", "add", "parser", issue.ID)
+	if err != nil {
+		t.Fatalf("alias add failed: %v", err)
+	}
+	if !strings.Contains(output, "parser now refers to "+issue.ID) {
+		t.Errorf("expected a success message, got: %s", output)
+	}
+	if _, err := runMint(t, "alias", "add", "parser"); err == nil {
+		t.Error("expected alias add without an issue to fail")
+	}
+	store, _ = LoadStore(filePath)
+	other, _ := store.AddIssue("Write lexer")
+	_ = store.Save(filePath)
+	if _, err := runMint(t, "alias", "add", "parser", other.ID); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("expected moving the alias to need --force, got %v", err)
+	}
+	if _, err := runMint(t, "alias", "add", "parser", other.ID, "--force"); err != nil {
+		t.Fatalf("alias add --force failed: %v", err)
+	}
+	if _, err := runMint(t, "alias", "add", "parser", issue.ID, "--force"); err != nil {
+		t.Fatalf("alias add --force failed: %v", err)
+	}
+
+	output, err = runMint(t, "alias", "list")
+	if err != nil {
+		t.Fatalf("alias list failed: %v", err)
+	}
+	if !strings.Contains(output, "parser "+issue.ID+" open Write parser") {
+		t.Errorf("expected the alias to be listed, got: %s", output)
+	}
+
+	// Using an alias works like the ID, with a note
+	cmd := newCommand()
+	var out, errOut bytes.Buffer
+	cmd.Writer, cmd.ErrWriter = &out, &errOut
+	if err := cmd.Run(context.Background(), []string{"mint", "show", "parser"}); err != nil {
+		t.Fatalf("show by alias failed: %v", err)
+	}
+	if !strings.Contains(stripANSI(out.String()), "Title   Write parser") {
+		t.Errorf("expected the issue, got: %s", out.String())
+	}
+	if want := "note: parser is an alias for " + issue.ID; !strings.Contains(errOut.String(), want) {
+		t.Errorf("expected %q, got %q", want, errOut.String())
+	}
+
+	if _, err := runMint(t, "alias", "remove", "parser"); err != nil {
+		t.Fatalf("alias remove failed: %v", err)
+	}
+	if _, err := runMint(t, "show", "parser"); err == nil {
+		t.Error("expected the removed alias not to resolve")
+	}
+}
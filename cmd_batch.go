@@ -0,0 +1,548 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// batchOp is a single operation in a batch script
+// Text lines and JSON lines are both parsed into this form
+type batchOp struct {
+	Op              string   `json:"op"`
+	ID              string   `json:"id,omitempty"`
+	Title           string   `json:"title,omitempty"`
+	Description     string   `json:"description,omitempty"`
+	Comment         string   `json:"comment,omitempty"`
+	Reason          string   `json:"reason,omitempty"`
+	Resolution      string   `json:"resolution,omitempty"`
+	Of              string   `json:"of,omitempty"`
+	DependsOn       []string `json:"depends_on,omitempty"`
+	Blocks          []string `json:"blocks,omitempty"`
+	RemoveDependsOn []string `json:"remove_depends_on,omitempty"`
+	RemoveBlocks    []string `json:"remove_blocks,omitempty"`
+	As              string   `json:"as,omitempty"`
+	Force           bool     `json:"force,omitempty"`
+	Cascade         bool     `json:"cascade,omitempty"`
+
+	line int
+}
+
+// batchOpNames maps every accepted operation name (including the CLI
+// aliases) to its canonical name
+var batchOpNames = map[string]string{
+	"create":  "create",
+	"add":     "create",
+	"new":     "create",
+	"c":       "create",
+	"a":       "create",
+	"n":       "create",
+	"update":  "update",
+	"u":       "update",
+	"close":   "close",
+	"cl":      "close",
+	"open":    "open",
+	"o":       "open",
+	"reopen":  "open",
+	"delete":  "delete",
+	"d":       "delete",
+	"comment": "comment",
+}
+
+// batchFlagNames maps every accepted flag name (including the CLI aliases)
+// to the batchOp field it sets
+var batchFlagNames = map[string]string{
+	"title":             "title",
+	"t":                 "title",
+	"description":       "description",
+	"comment":           "comment",
+	"c":                 "comment",
+	"reason":            "reason",
+	"resolution":        "resolution",
+	"of":                "of",
+	"depends-on":        "depends-on",
+	"d":                 "depends-on",
+	"blocks":            "blocks",
+	"b":                 "blocks",
+	"remove-depends-on": "remove-depends-on",
+	"rd":                "remove-depends-on",
+	"remove-blocks":     "remove-blocks",
+	"rb":                "remove-blocks",
+	"as":                "as",
+	"force":             "force",
+	"cascade":           "cascade",
+}
+
+// batchBoolFlags are the flags that don't take a value
+var batchBoolFlags = map[string]bool{
+	"force":   true,
+	"cascade": true,
+}
+
+func batchAction(_ context.Context, cmd *cli.Command) error {
+	ops, err := parseBatch(cmd.Root().Reader)
+	if err != nil {
+		return err
+	}
+
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := openStore(filePath)
+	if err != nil {
+		return err
+	}
+	store.aliasNotes = cmd.Root().ErrWriter
+
+	b := newBatch(store)
+	for _, op := range ops {
+		if err := b.apply(op); err != nil {
+			return fmt.Errorf("line %d: %w (no changes were saved)", op.line, err)
+		}
+	}
+
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+	if _, err := fmt.Fprintf(w, "\x1b[1;32m✔︎ Applied %d operations\x1b[0m\n\n", len(ops)); err != nil {
+		return err
+	}
+	for i, op := range ops {
+		ref := "$" + strconv.Itoa(i+1)
+		if op.As != "" {
+			ref += " @" + op.As
+		}
+		title := ""
+		if issue := store.Issues[b.results[i]]; issue != nil {
+			title = issue.Title
+		}
+		if _, err := fmt.Fprintf(w, "   %-7s %s %s \033[38;5;8m%s\033[0m\n", op.Op, store.FormatID(b.results[i]), title, ref); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintln(w)
+	return err
+}
+
+// parseBatch reads a batch script, one operation per line
+// Blank lines and lines starting with # are ignored
+// Lines starting with { are decoded as JSON operations
+func parseBatch(r io.Reader) ([]*batchOp, error) {
+	var ops []*batchOp
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var op *batchOp
+		var err error
+		if strings.HasPrefix(line, "{") {
+			op, err = parseBatchJSON(line)
+		} else {
+			op, err = parseBatchLine(line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		op.line = lineNum
+		ops = append(ops, op)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("no operations provided")
+	}
+	return ops, nil
+}
+
+// parseBatchJSON decodes a JSON operation like {"op":"create","title":"..."}
+func parseBatchJSON(line string) (*batchOp, error) {
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.DisallowUnknownFields()
+	op := &batchOp{}
+	if err := dec.Decode(op); err != nil {
+		return nil, fmt.Errorf("invalid JSON operation: %w", err)
+	}
+	name, ok := batchOpNames[op.Op]
+	if !ok {
+		return nil, fmt.Errorf("unknown operation %q", op.Op)
+	}
+	op.Op = name
+	return op, nil
+}
+
+// parseBatchLine parses a command line like `create "Title" --depends-on $1`
+// using the same operation and flag names as the CLI
+func parseBatchLine(line string) (*batchOp, error) {
+	args, err := splitArgs(line)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 0 && args[0] == "mint" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("missing operation")
+	}
+
+	name, ok := batchOpNames[args[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown operation %q", args[0])
+	}
+	op := &batchOp{Op: name}
+
+	var positional []string
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+
+		flagName := strings.TrimLeft(arg, "-")
+		value, hasValue := "", false
+		if before, after, found := strings.Cut(flagName, "="); found {
+			flagName, value, hasValue = before, after, true
+		}
+		field, ok := batchFlagNames[flagName]
+		if !ok {
+			return nil, fmt.Errorf("unknown flag %q", arg)
+		}
+		if batchBoolFlags[field] {
+			if !hasValue {
+				value = "true"
+			}
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("flag %q takes true or false, got %q", arg, value)
+			}
+			if field == "force" {
+				op.Force = enabled
+			} else {
+				op.Cascade = enabled
+			}
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("flag %q requires a value", arg)
+			}
+			i++
+			value = args[i]
+		}
+
+		switch field {
+		case "title":
+			op.Title = value
+		case "description":
+			op.Description = value
+		case "comment":
+			op.Comment = value
+		case "reason":
+			op.Reason = value
+		case "resolution":
+			op.Resolution = value
+		case "of":
+			op.Of = value
+		case "depends-on":
+			op.DependsOn = append(op.DependsOn, value)
+		case "blocks":
+			op.Blocks = append(op.Blocks, value)
+		case "remove-depends-on":
+			op.RemoveDependsOn = append(op.RemoveDependsOn, value)
+		case "remove-blocks":
+			op.RemoveBlocks = append(op.RemoveBlocks, value)
+		case "as":
+			op.As = value
+		}
+	}
+
+	switch {
+	case op.Op == "create":
+		if len(positional) > 0 {
+			op.Title = positional[0]
+			positional = positional[1:]
+		}
+	case len(positional) > 0:
+		op.ID = positional[0]
+		positional = positional[1:]
+		if op.Op == "comment" && len(positional) > 0 {
+			op.Comment = positional[0]
+			positional = positional[1:]
+		}
+	}
+	if len(positional) > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", positional[0])
+	}
+	return op, nil
+}
+
+// splitArgs splits a line into arguments like a POSIX shell would,
+// honoring single quotes, double quotes and backslash escapes
+func splitArgs(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"':
+			switch {
+			case r == '"':
+				quote = 0
+			case r == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`, runes[i+1]):
+				i++
+				current.WriteRune(runes[i])
+			default:
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			current.WriteRune(runes[i])
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// batch applies operations to a single in-memory store and tracks the
+// issue each operation touched so later operations can refer to it
+type batch struct {
+	store   *Store
+	results []string
+	names   map[string]string
+}
+
+func newBatch(store *Store) *batch {
+	return &batch{
+		store: store,
+		names: make(map[string]string),
+	}
+}
+
+// resolve turns a reference into a full issue ID
+// $N refers to the issue touched by the Nth operation, @name refers to an
+// issue named with --as, and anything else is resolved as a (partial) ID
+func (b *batch) resolve(ref string) (string, error) {
+	var id string
+	switch {
+	case strings.HasPrefix(ref, "$"):
+		n, err := strconv.Atoi(ref[1:])
+		if err != nil || n < 1 || n > len(b.results) {
+			return "", fmt.Errorf("unknown reference %s", ref)
+		}
+		id = b.results[n-1]
+	case strings.HasPrefix(ref, "@"):
+		named, ok := b.names[ref[1:]]
+		if !ok {
+			return "", fmt.Errorf("unknown reference %s", ref)
+		}
+		id = named
+	default:
+		return b.store.ResolveIssueID(ref)
+	}
+	if _, exists := b.store.Issues[id]; !exists {
+		return "", fmt.Errorf("issue %s (%s) no longer exists", id, ref)
+	}
+	return id, nil
+}
+
+// resolveAll resolves every reference in refs
+func (b *batch) resolveAll(refs []string) ([]string, error) {
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		id, err := b.resolve(ref)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// apply executes a single operation against the store
+func (b *batch) apply(op *batchOp) error {
+	if op.As != "" {
+		if _, exists := b.names[op.As]; exists {
+			return fmt.Errorf("name @%s is already in use", op.As)
+		}
+	}
+
+	if op.Op != "close" && (op.Resolution != "" || op.Of != "") {
+		return fmt.Errorf("resolution and of are only allowed when closing")
+	}
+
+	var id string
+	var err error
+	if op.Op == "create" {
+		id, err = b.create(op)
+	} else {
+		id, err = b.modify(op)
+	}
+	if err != nil {
+		return err
+	}
+
+	b.results = append(b.results, id)
+	if op.As != "" {
+		b.names[op.As] = id
+	}
+	return nil
+}
+
+func (b *batch) create(op *batchOp) (string, error) {
+	if op.Title == "" {
+		return "", fmt.Errorf("title is required")
+	}
+
+	dependsOnIDs, err := b.resolveAll(op.DependsOn)
+	if err != nil {
+		return "", fmt.Errorf("dependency issue not found: %w", err)
+	}
+	blocksIDs, err := b.resolveAll(op.Blocks)
+	if err != nil {
+		return "", fmt.Errorf("blocked issue not found: %w", err)
+	}
+
+	issue, err := b.store.AddIssue(op.Title)
+	if err != nil {
+		return "", err
+	}
+	for _, depID := range dependsOnIDs {
+		if err := b.store.AddDependency(issue.ID, depID); err != nil {
+			return "", err
+		}
+	}
+	for _, blockID := range blocksIDs {
+		if err := b.store.AddBlocker(issue.ID, blockID); err != nil {
+			return "", err
+		}
+	}
+	if op.Description != "" {
+		if err := b.store.AddComment(issue.ID, op.Description); err != nil {
+			return "", err
+		}
+	}
+	if op.Comment != "" {
+		if err := b.store.AddComment(issue.ID, op.Comment); err != nil {
+			return "", err
+		}
+	}
+	return issue.ID, nil
+}
+
+func (b *batch) modify(op *batchOp) (string, error) {
+	if op.ID == "" {
+		return "", fmt.Errorf("issue ID is required")
+	}
+	id, err := b.resolve(op.ID)
+	if err != nil {
+		return "", err
+	}
+
+	switch op.Op {
+	case "close":
+		// --of on its own means the issue is a duplicate, as with close
+		resolution := op.Resolution
+		if resolution == "" {
+			resolution = resolutionDone
+			if op.Of != "" {
+				resolution = resolutionDuplicate
+			}
+		}
+		var duplicateOf string
+		if op.Of != "" {
+			if duplicateOf, err = b.resolve(op.Of); err != nil {
+				return "", fmt.Errorf("duplicate issue not found: %w", err)
+			}
+		}
+		_, err := b.store.CloseIssues(id, resolution, op.Reason, duplicateOf, op.Cascade, op.Force)
+		return id, err
+	case "open":
+		return id, b.store.ReopenIssue(id)
+	case "delete":
+		return id, b.store.DeleteIssue(id)
+	case "comment":
+		if op.Comment == "" {
+			return "", fmt.Errorf("comment is required")
+		}
+		return id, b.store.AddComment(id, op.Comment)
+	}
+
+	if op.Title != "" {
+		if err := b.store.UpdateIssueTitle(id, op.Title); err != nil {
+			return "", err
+		}
+	}
+
+	relationships := []struct {
+		refs  []string
+		apply func(issueID, otherID string) error
+	}{
+		{op.DependsOn, b.store.AddDependency},
+		{op.Blocks, b.store.AddBlocker},
+		{op.RemoveDependsOn, b.store.RemoveDependency},
+		{op.RemoveBlocks, b.store.RemoveBlocker},
+	}
+	for _, rel := range relationships {
+		otherIDs, err := b.resolveAll(rel.refs)
+		if err != nil {
+			return "", err
+		}
+		for _, otherID := range otherIDs {
+			if err := rel.apply(id, otherID); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if op.Comment != "" {
+		if err := b.store.AddComment(id, op.Comment); err != nil {
+			return "", err
+		}
+	}
+	return id, nil
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBatchCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	script := `# Set up the work
+create "Set up CI" --as setup --description "Use GitHub Actions"
+create "Write tests" --depends-on @setup
+mint create 'Ship it' -d $2 -d @setup
+
+update $3 --comment "Waiting on tests"
+`
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+	cmd.Reader = strings.NewReader(script)
+
+	err := cmd.Run(context.Background(), []string{"mint", "batch"})
+	if err != nil {
+		t.Fatalf("batch command failed: %v", err)
+	}
+
+	store, _ := LoadStore(filePath)
+	if len(store.Issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d", len(store.Issues))
+	}
+
+	byTitle := make(map[string]*Issue)
+	for _, issue := range store.Issues {
+		byTitle[issue.Title] = issue
+	}
+	setup, tests, ship := byTitle["Set up CI"], byTitle["Write tests"], byTitle["Ship it"]
+	if setup == nil || tests == nil || ship == nil {
+		t.Fatalf("expected all issues to be created, got %v", byTitle)
+	}
+
+	if len(setup.Comments) != 1 || setup.Comments[0] != "Use GitHub Actions" {
+		t.Errorf("expected description comment, got %v", setup.Comments)
+	}
+	if len(tests.DependsOn) != 1 || tests.DependsOn[0] != setup.ID {
+		t.Errorf("expected tests to depend on %s, got %v", setup.ID, tests.DependsOn)
+	}
+	if len(ship.DependsOn) != 2 || ship.DependsOn[0] != tests.ID || ship.DependsOn[1] != setup.ID {
+		t.Errorf("expected ship to depend on [%s %s], got %v", tests.ID, setup.ID, ship.DependsOn)
+	}
+	if len(setup.Blocks) != 2 {
+		t.Errorf("expected setup to block 2 issues, got %v", setup.Blocks)
+	}
+	if len(ship.Comments) != 1 || ship.Comments[0] != "Waiting on tests" {
+		t.Errorf("expected comment on ship, got %v", ship.Comments)
+	}
+
+	output := stripANSI(buf.String())
+	if !strings.Contains(output, "✔︎ Applied 4 operations") {
+		t.Errorf("expected output to contain '✔︎ Applied 4 operations', got: %s", output)
+	}
+	if !strings.Contains(output, setup.ID+" Set up CI $1 @setup") {
+		t.Errorf("expected output to list setup issue with its references, got: %s", output)
+	}
+}
+
+func TestBatchCommand_JSONOperations(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	existing, _ := store.AddIssue("Existing issue")
+	_ = store.Save(filePath)
+
+	script := `{"op":"create","title":"New issue","blocks":["` + existing.ID + `"],"as":"new"}
+{"op":"close","id":"@new","reason":"Done"}
+{"op":"comment","id":"` + existing.ID + `","comment":"Unblocked"}
+`
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+	cmd.Reader = strings.NewReader(script)
+
+	err := cmd.Run(context.Background(), []string{"mint", "batch"})
+	if err != nil {
+		t.Fatalf("batch command failed: %v", err)
+	}
+
+	store, _ = LoadStore(filePath)
+	if len(store.Issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(store.Issues))
+	}
+
+	updated, _ := store.GetIssue(existing.ID)
+	if len(updated.DependsOn) != 1 {
+		t.Fatalf("expected existing issue to have 1 dependency, got %v", updated.DependsOn)
+	}
+	created, _ := store.GetIssue(updated.DependsOn[0])
+	if created.Status != "closed" {
+		t.Errorf("expected created issue to be closed, got '%s'", created.Status)
+	}
+	if !store.IsReady(updated) {
+		t.Error("expected existing issue to be ready after its blocker was closed")
+	}
+	if len(updated.Comments) != 1 || updated.Comments[0] != "Unblocked" {
+		t.Errorf("expected comment 'Unblocked', got %v", updated.Comments)
+	}
+}
+
+func TestBatchCommand_FailureSavesNothing(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	existing, _ := store.AddIssue("Existing issue")
+	_ = store.Save(filePath)
+
+	script := `create "First"
+close ` + existing.ID + `
+update mint-nonexistent --title "Nope"
+`
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+	cmd.Reader = strings.NewReader(script)
+
+	err := cmd.Run(context.Background(), []string{"mint", "batch"})
+	if err == nil {
+		t.Fatal("expected error for unknown issue")
+	}
+	if !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("expected error to mention line 3, got: %v", err)
+	}
+
+	store, _ = LoadStore(filePath)
+	if len(store.Issues) != 1 {
+		t.Errorf("expected store to be unchanged with 1 issue, got %d", len(store.Issues))
+	}
+	unchanged, _ := store.GetIssue(existing.ID)
+	if unchanged.Status != "open" {
+		t.Errorf("expected existing issue to remain open, got '%s'", unchanged.Status)
+	}
+}
+
+func TestBatchCommand_CloseDependencies(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	run := func(script string) error {
+		cmd := newCommand()
+		cmd.Writer = &bytes.Buffer{}
+		cmd.Reader = strings.NewReader(script)
+		return cmd.Run(context.Background(), []string{"mint", "batch"})
+	}
+
+	if err := run("create Design --as design\ncreate Build -d @design\nclose $2\n"); err == nil || !strings.Contains(err.Error(), "depends on open issues") {
+		t.Fatalf("expected closing an issue with open dependencies to fail, got %v", err)
+	}
+	if err := run("create Design --as design\ncreate Build -d @design\ncreate Ship -d $2\nclose @design --cascade\ncreate Review\ncreate Merge -d $5\nclose $6 --force\n"); err != nil {
+		t.Fatalf("batch command failed: %v", err)
+	}
+
+	store, _ := LoadStore(filePath)
+	for _, issue := range store.Issues {
+		want := "closed"
+		if issue.Title == "Review" {
+			want = "open"
+		}
+		if issue.Status != want {
+			t.Errorf("expected %s to be %s, got %s", issue.Title, want, issue.Status)
+		}
+	}
+}
+
+func TestBatchCommand_CloseResolution(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	run := func(script string) error {
+		cmd := newCommand()
+		cmd.Writer = &bytes.Buffer{}
+		cmd.Reader = strings.NewReader(script)
+		return cmd.Run(context.Background(), []string{"mint", "batch"})
+	}
+
+	for _, script := range []string{
+		"create Crash\nclose $1 --resolution fixed\n",
+		"create Crash\nclose $1 --resolution wontfix --of $1\n",
+		"create Crash\nupdate $1 --resolution wontfix\n",
+	} {
+		if err := run(script); err == nil {
+			t.Errorf("expected %q to fail", script)
+		}
+	}
+
+	if err := run("create Crash --as crash\ncreate \"Crash again\"\nclose $2 --of @crash\ncreate Idea\nclose $4 --resolution wontfix\n"); err != nil {
+		t.Fatalf("batch command failed: %v", err)
+	}
+	store, _ := LoadStore(filePath)
+	byTitle := make(map[string]*Issue)
+	for _, issue := range store.Issues {
+		byTitle[issue.Title] = issue
+	}
+	if dup := byTitle["Crash again"]; dup.Resolution != resolutionDuplicate || dup.DuplicateOf != byTitle["Crash"].ID {
+		t.Errorf("expected a duplicate of %s, got %s of %q", byTitle["Crash"].ID, dup.Resolution, dup.DuplicateOf)
+	}
+	if idea := byTitle["Idea"]; idea.Resolution != resolutionWontfix {
+		t.Errorf("expected wontfix, got %s", idea.Resolution)
+	}
+}
+
+func TestBatchCommand_CreateAndCloseRunsCloseHooks(t *testing.T) {
+	root := t.TempDir()
+	filePath := filepath.Join(root, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	writeHook(t, root, "pre-close", `echo "tests are failing" >&2; exit 1`)
+
+	run := func() error {
+		cmd := newCommand()
+		cmd.Writer = &bytes.Buffer{}
+		cmd.Reader = strings.NewReader("create \"Write parser\"\nclose $1\n")
+		return cmd.Run(context.Background(), []string{"mint", "batch"})
+	}
+
+	if err := run(); err == nil || !strings.Contains(err.Error(), "rejected by the pre-close hook") {
+		t.Fatalf("expected the pre-close hook to reject the batch, got %v", err)
+	}
+	store, _ := LoadStore(filePath)
+	if len(store.Issues) != 0 {
+		t.Errorf("expected nothing to be saved, got %d issues", len(store.Issues))
+	}
+
+	writeHook(t, root, "pre-close", `exit 0`)
+	writeHook(t, root, "on-close", `echo "$MINT_EVENT" >> events.log`)
+	if err := run(); err != nil {
+		t.Fatalf("batch command failed: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "events.log")); string(data) != "close\n" {
+		t.Errorf("expected the on-close hook to run, got %q", data)
+	}
+}
+
+func TestBatchCommand_UnknownReference(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+	cmd.Reader = strings.NewReader("create \"A\" --depends-on @missing\n")
+
+	err := cmd.Run(context.Background(), []string{"mint", "batch"})
+	if err == nil {
+		t.Fatal("expected error for unknown reference")
+	}
+	if !strings.Contains(err.Error(), "unknown reference @missing") {
+		t.Errorf("expected unknown reference error, got: %v", err)
+	}
+}
+
+func TestBatchCommand_Empty(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+	cmd.Reader = strings.NewReader("\n# nothing here\n")
+
+	err := cmd.Run(context.Background(), []string{"mint", "batch"})
+	if err == nil {
+		t.Fatal("expected error for empty batch")
+	}
+}
+
+func TestParseBatchLine(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    batchOp
+		wantErr bool
+	}{
+		{
+			line: `create "Fix the \"parser\"" --as fix`,
+			want: batchOp{Op: "create", Title: `Fix the "parser"`, As: "fix"},
+		},
+		{
+			line: `u mint-a8 -t 'New title' --rd=$1 --rb @x -c note`,
+			want: batchOp{Op: "update", ID: "mint-a8", Title: "New title", RemoveDependsOn: []string{"$1"}, RemoveBlocks: []string{"@x"}, Comment: "note"},
+		},
+		{
+			line: `comment $2 "Looks good"`,
+			want: batchOp{Op: "comment", ID: "$2", Comment: "Looks good"},
+		},
+		{
+			line: `close mint-a8 --force --cascade=false`,
+			want: batchOp{Op: "close", ID: "mint-a8", Force: true},
+		},
+		{
+			line: `close mint-lw --resolution duplicate --of @crash`,
+			want: batchOp{Op: "close", ID: "mint-lw", Resolution: "duplicate", Of: "@crash"},
+		},
+		{line: `frobnicate mint-a8`, wantErr: true},
+		{line: `close mint-a8 --force=maybe`, wantErr: true},
+		{line: `close mint-a8 --bogus x`, wantErr: true},
+		{line: `create "unterminated`, wantErr: true},
+		{line: `close mint-a8 extra`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseBatchLine(tt.line)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseBatchLine(%q) expected error", tt.line)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseBatchLine(%q) failed: %v", tt.line, err)
+			continue
+		}
+		if got.Op != tt.want.Op || got.ID != tt.want.ID || got.Title != tt.want.Title ||
+			got.Comment != tt.want.Comment || got.As != tt.want.As ||
+			got.Force != tt.want.Force || got.Cascade != tt.want.Cascade ||
+			got.Resolution != tt.want.Resolution || got.Of != tt.want.Of ||
+			strings.Join(got.RemoveDependsOn, ",") != strings.Join(tt.want.RemoveDependsOn, ",") ||
+			strings.Join(got.RemoveBlocks, ",") != strings.Join(tt.want.RemoveBlocks, ",") {
+			t.Errorf("parseBatchLine(%q) = %+v, want %+v", tt.line, *got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+func checklistRunAction(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("checklist name is required")
+	}
+
+	name := cmd.Args().First()
+
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	root, err := projectRoot()
+	if err != nil {
+		return err
+	}
+	checklist, err := LoadChecklist(root, name)
+	if err != nil {
+		return err
+	}
+	vars, err := parseTemplateVars(cmd.StringSlice("var"))
+	if err != nil {
+		return err
+	}
+	if checklist, err = checklist.Expand(vars); err != nil {
+		return err
+	}
+
+	store, err := openStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	runID := cmd.String("run")
+	if runID == "" {
+		runID = store.nextRunID(name)
+	}
+	issues, err := store.RunChecklist(checklist, runID)
+	if err != nil {
+		return err
+	}
+
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+	if _, err := fmt.Fprintf(w, "\x1b[1;32m✔︎ Started checklist run %s with %d steps\x1b[0m\n", runID, len(issues)); err != nil {
+		return err
+	}
+	return printChecklistRun(w, runID, issues, store)
+}
+
+func checklistStatusAction(_ context.Context, cmd *cli.Command) error {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		_, err := fmt.Fprintln(w, "No issues file found.")
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Args().Len() > 0 {
+		runID := strings.TrimPrefix(cmd.Args().First(), runLabelPrefix)
+		issues := store.ChecklistRun(runID)
+		if len(issues) == 0 {
+			return fmt.Errorf("checklist run %s not found", runID)
+		}
+		return printChecklistRun(w, runID, issues, store)
+	}
+
+	// Without a run, summarize every run
+	var runIDs []string
+	for _, issue := range store.Issues {
+		for _, label := range issue.Labels {
+			if runID, ok := strings.CutPrefix(label, runLabelPrefix); ok && !slices.Contains(runIDs, runID) {
+				runIDs = append(runIDs, runID)
+			}
+		}
+	}
+	if len(runIDs) == 0 {
+		_, err := fmt.Fprintln(w, "No checklist runs found.")
+		return err
+	}
+	slices.Sort(runIDs)
+	for _, runID := range runIDs {
+		issues := store.ChecklistRun(runID)
+		done := countClosed(issues)
+		if _, err := fmt.Fprintf(w, "%s \033[38;5;8m%d of %d done\033[0m\n", runID, done, len(issues)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printChecklistRun prints the steps of a run in order, marking the next
+// open step
+func printChecklistRun(w io.Writer, runID string, issues []*Issue, store *Store) error {
+	done := countClosed(issues)
+	if _, err := fmt.Fprintf(w, "\n\033[1m\033[38;5;5m%s\033[0m \033[38;5;8m%d of %d done\033[0m\n\n", runID, done, len(issues)); err != nil {
+		return err
+	}
+
+	maxIDLen := 0
+	for _, issue := range issues {
+		maxIDLen = max(maxIDLen, len(issue.ID))
+	}
+	next := true
+	for _, issue := range issues {
+		marker := ""
+		if next && issue.Status != "closed" {
+			marker = " \033[38;5;8m← next\033[0m"
+			next = false
+		}
+		padding := strings.Repeat(" ", 1+maxIDLen-len(issue.ID))
+		if _, err := fmt.Fprintf(w, "   %s%s%s %s%s\n", store.FormatID(issue.ID), padding, issue.Status, issue.Title, marker); err != nil {
+			return err
+		}
+	}
+
+	if done == len(issues) {
+		_, err := fmt.Fprintf(w, "\n\x1b[1;32m✔︎ Checklist run complete\x1b[0m\n")
+		return err
+	}
+	return nil
+}
+
+func countClosed(issues []*Issue) int {
+	closed := 0
+	for _, issue := range issues {
+		if issue.Status == "closed" {
+			closed++
+		}
+	}
+	return closed
+}
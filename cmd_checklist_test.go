@@ -0,0 +1,58 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestChecklistCommands(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	writeChecklist(t, tmpDir, "release", releaseChecklist)
+
+	output, err := runMint(t, "checklist", "run", "release", "--var", "version=1.2")
+	if err != nil {
+		t.Fatalf("checklist run failed: %v", err)
+	}
+	if !strings.Contains(output, "✔︎ Started checklist run release-1 with 3 steps") {
+		t.Errorf("expected started message, got: %s", output)
+	}
+	if _, err := runMint(t, "checklist", "run", "release", "--var", "version=1.3", "--run", "v1.3"); err != nil {
+		t.Fatalf("checklist run --run failed: %v", err)
+	}
+
+	store, _ := LoadStore(filePath)
+	first := store.ChecklistRun("release-1")[0]
+	if err := store.CloseIssue(first.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Save(filePath)
+
+	output, err = runMint(t, "checklist", "status", "release-1")
+	if err != nil {
+		t.Fatalf("checklist status failed: %v", err)
+	}
+	if !strings.Contains(output, "release-1 1 of 3 done") {
+		t.Errorf("expected progress, got: %s", output)
+	}
+	if !strings.Contains(output, "Tag v1.2 ← next") {
+		t.Errorf("expected the second step to be next, got: %s", output)
+	}
+
+	output, err = runMint(t, "checklist", "status")
+	if err != nil {
+		t.Fatalf("checklist status without a run failed: %v", err)
+	}
+	if !strings.Contains(output, "release-1 1 of 3 done") || !strings.Contains(output, "v1.3 0 of 3 done") {
+		t.Errorf("expected a summary of every run, got: %s", output)
+	}
+
+	if _, err := runMint(t, "checklist", "status", "release-9"); err == nil {
+		t.Error("expected error for an unknown run")
+	}
+	if _, err := runMint(t, "checklist", "run", "release"); err == nil || !strings.Contains(err.Error(), "version") {
+		t.Errorf("expected missing variable error, got %v", err)
+	}
+}
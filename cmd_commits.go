@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+func syncCommitsAction(_ context.Context, cmd *cli.Command) error {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+	store.aliasNotes = cmd.Root().ErrWriter
+
+	root, err := projectRoot()
+	if err != nil {
+		return err
+	}
+	commits, err := readCommits(root, cmd.String("since"))
+	if err != nil {
+		return err
+	}
+
+	result, err := SyncCommits(store, commits)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Closed) > 0 || len(result.Commented) > 0 {
+		if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
+			return err
+		}
+	}
+
+	w := cmd.Root().Writer
+	if _, err := fmt.Fprintf(w, "\x1b[1;32m✔︎ Synced %d commits\x1b[0m (%d closed, %d commented)\n",
+		result.Commits, len(result.Closed), len(result.Commented)); err != nil {
+		return err
+	}
+
+	maxIDLen := 0
+	for _, issue := range append(result.Closed, result.Commented...) {
+		maxIDLen = max(maxIDLen, len(issue.ID))
+	}
+	sections := []struct {
+		header string
+		issues []*Issue
+	}{
+		{"Closed", result.Closed},
+		{"Commented", result.Commented},
+	}
+	for _, section := range sections {
+		if len(section.issues) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "\n\033[1m\033[38;5;5m%s\033[0m\n", section.header); err != nil {
+			return err
+		}
+		if err := printIssueList(w, section.issues, maxIDLen, store); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintln(w)
+	return err
+}
+
+func installHooksAction(_ context.Context, cmd *cli.Command) error {
+	root, err := projectRoot()
+	if err != nil {
+		return err
+	}
+
+	hookPath, err := installPostCommitHook(root, cmd.Bool("force"))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(cmd.Root().Writer, "\x1b[1;32m✔︎ Installed %s\x1b[0m\n\nCommits that say \"Fixes <id>\" now close the issue.\n\n", hookPath)
+	return err
+}
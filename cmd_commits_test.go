@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSyncCommitsCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	initGitRepo(t, tmpDir)
+
+	store := NewStore()
+	issue, _ := store.AddIssue("Parser crash")
+	if err := store.Save(filePath); err != nil {
+		t.Fatal(err)
+	}
+	first := gitCommitMessage(t, tmpDir, "Unrelated")
+	gitCommitMessage(t, tmpDir, "Fix parser crash\n\nFixes: "+issue.ID)
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "sync-commits", "--since", first})
+	if err != nil {
+		t.Fatalf("sync-commits command failed: %v", err)
+	}
+
+	output := stripANSI(buf.String())
+	if !strings.Contains(output, "✔︎ Synced 1 commits (1 closed, 0 commented)") {
+		t.Errorf("expected sync summary, got: %s", output)
+	}
+	if !strings.Contains(output, issue.ID) {
+		t.Errorf("expected closed issue in output, got: %s", output)
+	}
+
+	store, _ = LoadStore(filePath)
+	closed, _ := store.GetIssue(issue.ID)
+	if closed.Status != "closed" {
+		t.Errorf("expected issue to be closed, got %s", closed.Status)
+	}
+}
+
+func TestInstallHooksCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("MINT_STORE_FILE", filepath.Join(tmpDir, "mint-issues.yaml"))
+	initGitRepo(t, tmpDir)
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "install-hooks"})
+	if err != nil {
+		t.Fatalf("install-hooks command failed: %v", err)
+	}
+
+	output := stripANSI(buf.String())
+	if !strings.Contains(output, "✔︎ Installed "+filepath.Join(tmpDir, ".git", "hooks", "post-commit")) {
+		t.Errorf("expected install message, got: %s", output)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// applyConfig configures output from the config before any command runs
+// An invalid config is left for the commands that read it to report, so
+// config set can still fix it
+func applyConfig(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return ctx, nil
+	}
+	cmd.Writer = applyColorConfig(cmd.Writer, cfg)
+	return ctx, nil
+}
+
+// expandCommand replaces a custom command from the config in args[1] with
+// its command line. Built-in commands can't be shadowed.
+func expandCommand(cmd *cli.Command, args []string, commands map[string]string) ([]string, error) {
+	if len(args) < 2 || strings.HasPrefix(args[1], "-") || cmd.Command(args[1]) != nil {
+		return args, nil
+	}
+	line, ok := commands[args[1]]
+	if !ok {
+		return args, nil
+	}
+	expanded, err := splitArgs(line)
+	if err != nil {
+		return nil, fmt.Errorf("command %s: %w", args[1], err)
+	}
+	if len(expanded) > 0 && expanded[0] == "mint" {
+		expanded = expanded[1:]
+	}
+	return append(append([]string{args[0]}, expanded...), args[2:]...), nil
+}
+
+func configGetAction(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("config key is required")
+	}
+	name := cmd.Args().First()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	value, ok := cfg.Get(name)
+	if !ok {
+		if err := validateConfigValue(name, ""); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintln(cmd.Root().Writer, value)
+	return err
+}
+
+func configSetAction(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() < 2 {
+		return fmt.Errorf("config key and value are required")
+	}
+	name, value := cmd.Args().Get(0), cmd.Args().Get(1)
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		// Still allow fixing a broken config file
+		root, rootErr := projectRoot()
+		if rootErr != nil {
+			return rootErr
+		}
+		userPath, pathErr := userConfigPath()
+		if pathErr != nil {
+			return pathErr
+		}
+		cfg = &Config{RepoPath: filepath.Join(root, repoConfigFileName), UserPath: userPath}
+	}
+
+	path := cfg.RepoPath
+	if cmd.Bool("user") {
+		path = cfg.UserPath
+	}
+	if err := SetConfigValue(path, name, value); err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+	if value == "" {
+		_, err = fmt.Fprintf(w, "\x1b[1;32m✔︎ Removed %s\x1b[0m from %s\n", name, path)
+	} else {
+		_, err = fmt.Fprintf(w, "\x1b[1;32m✔︎ Set %s = %s\x1b[0m in %s\n", name, value, path)
+	}
+	return err
+}
+
+func configListAction(_ context.Context, cmd *cli.Command) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	names := cfg.Names()
+	maxNameLen, maxValueLen := 0, 0
+	for _, name := range names {
+		maxNameLen = max(maxNameLen, len(name))
+		maxValueLen = max(maxValueLen, len(cfg.String(name)))
+	}
+
+	w := cmd.Root().Writer
+	for _, name := range names {
+		source := cfg.Source(name)
+		switch source {
+		case "repo config":
+			source += " " + cfg.RepoPath
+		case "user config":
+			source += " " + cfg.UserPath
+		}
+		if _, err := fmt.Fprintf(w, "%-*s  %-*s  \033[38;5;8m%s\033[0m\n", maxNameLen, name, maxValueLen, cfg.String(name), source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runMint runs the mint command with args and returns its output without colors
+func runMint(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+	err := runCommand(context.Background(), cmd, append([]string{"mint"}, args...))
+	return stripANSI(buf.String()), err
+}
+
+func TestCustomCommand(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	store := NewStore()
+	parser, _ := store.AddIssue("Write parser")
+	docs, _ := store.AddIssue("Write docs")
+	_ = store.AddDependency(docs.ID, parser.ID)
+	if err := store.Save(filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := runMint(t, "config", "set", "--user", "commands.r", "list --ready"); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	output, err := runMint(t, "r")
+	if err != nil {
+		t.Fatalf("custom command failed: %v", err)
+	}
+	if !strings.Contains(output, "Write parser") || strings.Contains(output, "Write docs") {
+		t.Errorf("expected only the ready issue, got: %s", output)
+	}
+}
+
+func TestConfigCommands(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("MINT_STORE_FILE", filepath.Join(tmpDir, "mint-issues.yaml"))
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	output, err := runMint(t, "config", "set", "list.limit", "3")
+	if err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	if !strings.Contains(output, "✔︎ Set list.limit = 3 in "+filepath.Join(tmpDir, ".mint.yaml")) {
+		t.Errorf("expected set message, got: %s", output)
+	}
+
+	if _, err := runMint(t, "config", "set", "--user", "author", "Alice"); err != nil {
+		t.Fatalf("config set --user failed: %v", err)
+	}
+
+	output, err = runMint(t, "config", "get", "list.limit")
+	if err != nil {
+		t.Fatalf("config get failed: %v", err)
+	}
+	if output != "3\n" {
+		t.Errorf("expected 3, got %q", output)
+	}
+
+	output, err = runMint(t, "config", "list")
+	if err != nil {
+		t.Fatalf("config list failed: %v", err)
+	}
+	for _, want := range []string{
+		"author           Alice",
+		"user config " + filepath.Join(configHome, "mint", "config.yaml"),
+		"list.limit       3",
+		"repo config " + filepath.Join(tmpDir, ".mint.yaml"),
+		"color            always",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected config list to contain %q, got: %s", want, output)
+		}
+	}
+
+	if _, err := runMint(t, "config", "set", "list.sort", "sideways"); err == nil {
+		t.Error("expected invalid value to be rejected")
+	}
+	if _, err := runMint(t, "config", "get", "nope"); err == nil {
+		t.Error("expected unknown key to be rejected")
+	}
+}
+
+func TestListCommand_ConfiguredSortAndLimit(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	writeConfigFile(t, filepath.Join(tmpDir, ".mint.yaml"), "list:\n  sort: title\n  limit: 2\n")
+
+	store := NewStore()
+	for _, title := range []string{"Charlie", "alpha", "Bravo"} {
+		_, _ = store.AddIssue(title)
+	}
+	if err := store.Save(filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := runMint(t, "list")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	alpha, bravo := strings.Index(output, "alpha"), strings.Index(output, "Bravo")
+	if alpha < 0 || bravo < 0 || alpha > bravo {
+		t.Errorf("expected issues sorted by title, got: %s", output)
+	}
+	if strings.Contains(output, "Charlie") || !strings.Contains(output, "(2 of 3)") {
+		t.Errorf("expected configured limit, got: %s", output)
+	}
+
+	// Flags override the config
+	output, err = runMint(t, "list", "--limit", "0", "--sort", "created")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if !strings.Contains(output, "Charlie") {
+		t.Errorf("expected --limit 0 to show every issue, got: %s", output)
+	}
+
+	if _, err := runMint(t, "list", "--sort", "sideways"); err == nil || !strings.Contains(err.Error(), "invalid sort order") {
+		t.Errorf("expected invalid sort error, got %v", err)
+	}
+}
+
+func TestCreateCommand_ConfiguredRequiredAndIDs(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	writeConfigFile(t, filepath.Join(tmpDir, ".mint.yaml"),
+		"create:\n  required: [description]\nid:\n  length: 8\n  alphabet: ab\n")
+
+	_, err := runMint(t, "create", "No description")
+	if err == nil || !strings.Contains(err.Error(), "--description is required") {
+		t.Errorf("expected required description error, got %v", err)
+	}
+
+	if _, err := runMint(t, "create", "Described", "--description", "Details"); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	store, _ := LoadStore(filePath)
+	issue := store.ListIssues()[0]
+	suffix := strings.TrimPrefix(issue.ID, "mint-")
+	if len(suffix) != 8 || strings.Trim(suffix, "ab") != "" {
+		t.Errorf("expected an 8-character ID from the configured alphabet, got %s", issue.ID)
+	}
+}
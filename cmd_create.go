@@ -8,21 +8,63 @@ import (
 )
 
 func createAction(_ context.Context, cmd *cli.Command) error {
-	if cmd.Args().Len() == 0 {
+	templateName := cmd.String("template")
+	if cmd.Args().Len() == 0 && templateName == "" {
 		return fmt.Errorf("title is required")
 	}
 
-	title := cmd.Args().First()
-
 	filePath, err := GetStoreFilePath()
 	if err != nil {
 		return err
 	}
 
-	store, err := LoadStore(filePath)
+	// A plain create is an empty template; the title argument and
+	// --description override the template's
+	tmpl := &Template{}
+	if templateName != "" {
+		root, err := projectRoot()
+		if err != nil {
+			return err
+		}
+		loaded, err := LoadTemplate(root, templateName)
+		if err != nil {
+			return err
+		}
+		vars, err := parseTemplateVars(cmd.StringSlice("var"))
+		if err != nil {
+			return err
+		}
+		if tmpl, err = loaded.Expand(vars); err != nil {
+			return err
+		}
+	} else if len(cmd.StringSlice("var")) > 0 {
+		return fmt.Errorf("--var requires --template")
+	}
+	if cmd.Args().Len() > 0 {
+		tmpl.Title = cmd.Args().First()
+	}
+	if description := cmd.String("description"); description != "" {
+		tmpl.Description = description
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	for _, name := range cfg.List("create.required") {
+		if name == "description" && tmpl.Description != "" {
+			continue
+		}
+		if len(cmd.StringSlice(name)) == 0 && cmd.String(name) == "" {
+			return fmt.Errorf("--%s is required (see create.required in config)", name)
+		}
+	}
+
+	store, err := openStore(filePath)
 	if err != nil {
 		return err
 	}
+	store.aliasNotes = cmd.Root().ErrWriter
 
 	// Pre-validate relationship IDs exist
 	dependsOnIDs := cmd.StringSlice("depends-on")
@@ -39,7 +81,16 @@ func createAction(_ context.Context, cmd *cli.Command) error {
 		}
 	}
 
-	issue, err := store.AddIssue(title)
+	if !cmd.Bool("no-dup-check") {
+		threshold := float64(cfg.Int("dups.threshold")) / 100
+		if similar := findSimilar(store, tmpl.Title, tmpl.Description, threshold); len(similar) > 0 {
+			if err := printSimilar(cmd.Root().ErrWriter, similar, store); err != nil {
+				return err
+			}
+		}
+	}
+
+	issue, subtasks, err := store.CreateFromTemplate(tmpl)
 	if err != nil {
 		return err
 	}
@@ -58,13 +109,6 @@ func createAction(_ context.Context, cmd *cli.Command) error {
 		}
 	}
 
-	// Add description as first comment if provided
-	if description := cmd.String("description"); description != "" {
-		if err := store.AddComment(issue.ID, description); err != nil {
-			return err
-		}
-	}
-
 	// Add comment if provided
 	if comment := cmd.String("comment"); comment != "" {
 		if err := store.AddComment(issue.ID, comment); err != nil {
@@ -72,12 +116,16 @@ func createAction(_ context.Context, cmd *cli.Command) error {
 		}
 	}
 
-	if err := store.Save(filePath); err != nil {
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	if _, err := fmt.Fprintf(w, "\x1b[1;32m✔︎ Created issue\x1b[0m\n"); err != nil {
+	message := "Created issue"
+	if len(subtasks) > 0 {
+		message = fmt.Sprintf("Created issue with %d subtasks", len(subtasks))
+	}
+	if _, err := fmt.Fprintf(w, "\x1b[1;32m✔︎ %s\x1b[0m\n", message); err != nil {
 		return err
 	}
 	return PrintIssueDetails(w, issue, store)
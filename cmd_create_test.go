@@ -765,3 +765,81 @@ func TestCreateCommandWithInvalidBlockedIssue(t *testing.T) {
 		t.Errorf("expected 0 issues after failed create, got %d", len(store.Issues))
 	}
 }
+
+func TestCreateCommandWithTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	writeTemplate(t, tmpDir, "release", releaseTemplate)
+
+	output, err := runMint(t, "create", "--template", "release", "--var", "version=1.2")
+	if err != nil {
+		t.Fatalf("create --template failed: %v", err)
+	}
+	if !strings.Contains(output, "✔︎ Created issue with 3 subtasks") || !strings.Contains(output, "Release 1.2") {
+		t.Errorf("expected created message and title, got: %s", output)
+	}
+
+	store, _ := LoadStore(filePath)
+	if len(store.Issues) != 4 {
+		t.Errorf("expected 4 issues, got %d", len(store.Issues))
+	}
+
+	// The title argument overrides the template's
+	output, err = runMint(t, "create", "Hotfix release", "-t", "release", "--var", "version=1.2.1")
+	if err != nil {
+		t.Fatalf("create --template with title failed: %v", err)
+	}
+	if !strings.Contains(output, "Hotfix release") {
+		t.Errorf("expected title override, got: %s", output)
+	}
+
+	// Missing variables leave the store untouched
+	if _, err := runMint(t, "create", "--template", "release"); err == nil || !strings.Contains(err.Error(), "version") {
+		t.Errorf("expected missing variable error, got %v", err)
+	}
+	if _, err := runMint(t, "create", "Plain", "--var", "version=1"); err == nil {
+		t.Error("expected error for --var without --template")
+	}
+	store, _ = LoadStore(filePath)
+	if len(store.Issues) != 8 {
+		t.Errorf("expected 8 issues, got %d", len(store.Issues))
+	}
+}
+
+func TestCreateCommand_WarnsAboutSimilarIssues(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	existing, _ := store.AddIssue("Write the parser")
+	_ = store.Save(filePath)
+
+	create := func(args ...string) string {
+		t.Helper()
+		cmd := newCommand()
+		var out, errOut bytes.Buffer
+		cmd.Writer, cmd.ErrWriter = &out, &errOut
+		if err := cmd.Run(context.Background(), append([]string{"mint", "create"}, args...)); err != nil {
+			t.Fatalf("create failed: %v", err)
+		}
+		return stripANSI(errOut.String())
+	}
+
+	warning := create("Write parser")
+	if !strings.Contains(warning, "similar open issues already exist") || !strings.Contains(warning, existing.ID+" open Write the parser (") {
+		t.Errorf("expected a warning naming %s, got %q", existing.ID, warning)
+	}
+	if warning := create("Write parser", "--no-dup-check"); warning != "" {
+		t.Errorf("expected no warning with --no-dup-check, got %q", warning)
+	}
+	if warning := create("Release 1.0"); warning != "" {
+		t.Errorf("expected no warning for a new issue, got %q", warning)
+	}
+
+	// The warning doesn't stop the issue being created
+	store, _ = LoadStore(filePath)
+	if len(store.Issues) != 4 {
+		t.Errorf("expected 4 issues, got %d", len(store.Issues))
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+func dupsAction(_ context.Context, cmd *cli.Command) error {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+	clusters := duplicateClusters(store, float64(cfg.Int("dups.threshold"))/100)
+	if len(clusters) == 0 {
+		_, err := fmt.Fprintln(w, "No likely duplicates")
+		return err
+	}
+
+	maxIDLen := 0
+	for _, cluster := range clusters {
+		for _, issue := range cluster {
+			maxIDLen = max(maxIDLen, len(issue.ID))
+		}
+	}
+	for _, cluster := range clusters {
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+		if err := printIssueList(w, cluster, maxIDLen, store); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "\n\033[38;5;8mCombine duplicates with: mint merge <keep-id> <duplicate-id>\033[0m\n")
+	return err
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDupsCommand(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	output, err := runMint(t, "dups")
+	if err != nil {
+		t.Fatalf("dups failed: %v", err)
+	}
+	if !strings.Contains(output, "No likely duplicates") {
+		t.Errorf("expected no duplicates in an empty store, got: %s", output)
+	}
+
+	store, _ := LoadStore(filePath)
+	first, _ := store.AddIssue("Fix typo in README")
+	second, _ := store.AddIssue("Fix README typo")
+	other, _ := store.AddIssue("Release 1.0")
+	_ = store.Save(filePath)
+
+	output, err = runMint(t, "dups")
+	if err != nil {
+		t.Fatalf("dups failed: %v", err)
+	}
+	if !strings.Contains(output, first.ID) || !strings.Contains(output, second.ID) || strings.Contains(output, other.ID) {
+		t.Errorf("expected only the README issues, got: %s", output)
+	}
+	if !strings.Contains(output, "mint merge") {
+		t.Errorf("expected a hint about merge, got: %s", output)
+	}
+
+	// A stricter threshold finds nothing
+	t.Setenv("MINT_DUPS_THRESHOLD", "100")
+	if output, _ := runMint(t, "dups"); !strings.Contains(output, "No likely duplicates") {
+		t.Errorf("expected no duplicates at 100%%, got: %s", output)
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+func editAction(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("issue ID is required")
+	}
+
+	id := cmd.Args().First()
+
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+	store.aliasNotes = cmd.Root().ErrWriter
+
+	// Resolve partial ID to full ID
+	fullID, err := store.ResolveIssueID(id)
+	if err != nil {
+		return err
+	}
+
+	issue, err := store.GetIssue(fullID)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp("", "mint-"+fullID+"-*.md")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+	original := renderEditDocument(issue, store)
+	text := original
+	var problem error
+	for {
+		written := renderEditDocumentText(text, problem)
+		if err := os.WriteFile(tmp.Name(), []byte(written), 0o600); err != nil {
+			return err
+		}
+		if err := runEditor(tmp.Name()); err != nil {
+			return err
+		}
+		// #nosec G304 -- the temporary file created above
+		data, err := os.ReadFile(tmp.Name())
+		if err != nil {
+			return err
+		}
+		edited := string(data)
+
+		if strings.TrimSpace(edited) == "" {
+			_, err := fmt.Fprintln(w, "Edit cancelled.")
+			return err
+		}
+		// Saving a rejected document unchanged gives up rather than looping
+		if problem != nil && edited == written {
+			return problem
+		}
+		if edited == original {
+			_, err := fmt.Fprintln(w, "No changes.")
+			return err
+		}
+
+		// Start from a fresh copy each time so a rejected attempt
+		// leaves nothing behind
+		store, err = LoadStore(filePath)
+		if err != nil {
+			return err
+		}
+		issue, err = store.GetIssue(fullID)
+		if err != nil {
+			return err
+		}
+
+		doc, err := parseEditDocument(edited)
+		if err == nil {
+			var changes []string
+			changes, err = ApplyEdit(store, issue, doc)
+			if err == nil {
+				if len(changes) == 0 {
+					_, err := fmt.Fprintln(w, "No changes.")
+					return err
+				}
+				break
+			}
+		}
+		problem = err
+		text = stripEditErrors(edited)
+	}
+
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "\x1b[1;32m✔︎ Issue updated\x1b[0m\n"); err != nil {
+		return err
+	}
+	return PrintIssueDetails(w, issue, store)
+}
+
+// renderEditDocumentText adds an error from a previous attempt to the top of
+// the front matter of text
+func renderEditDocumentText(text string, problem error) string {
+	if problem == nil {
+		return text
+	}
+	var b strings.Builder
+	rest, ok := strings.CutPrefix(text, "---\n")
+	if ok {
+		b.WriteString("---\n")
+	}
+	for line := range strings.SplitSeq(problem.Error(), "\n") {
+		fmt.Fprintf(&b, "# ERROR: %s\n", line)
+	}
+	b.WriteString("#\n")
+	b.WriteString(rest)
+	return b.String()
+}
+
+// stripEditErrors removes the error lines added by renderEditDocumentText
+func stripEditErrors(text string) string {
+	rest, ok := strings.CutPrefix(text, "---\n")
+	if !ok {
+		return text
+	}
+	for {
+		line, after, found := strings.Cut(rest, "\n")
+		if !found || (!strings.HasPrefix(line, "# ERROR: ") && line != "#") {
+			break
+		}
+		rest = after
+	}
+	return "---\n" + rest
+}
+
+// runEditor opens path in $VISUAL or $EDITOR, falling back to vi
+// The editor is run through the shell so it can include arguments
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// #nosec G204 -- the editor is chosen by the user running mint
+	c := exec.Command("sh", "-c", editor+` "$1"`, "--", path)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setEditor points EDITOR at a shell script that edits the file in $1
+func setEditor(t *testing.T, script string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "editor.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TMPDIR", t.TempDir())
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", path)
+}
+
+func TestEditCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	issue1, _ := store.AddIssue("Original title")
+	issue2, _ := store.AddIssue("Issue 2")
+	_ = store.Save(filePath)
+
+	setEditor(t, `sed -i.bak -e 's/^title: .*/title: "Edited title"/' -e 's/^depends_on: \[\]/depends_on: [`+issue2.ID+`]/' "$1"
+printf '\n<!-- comment -->\nAdded in the editor\n' >> "$1"`)
+
+	output, err := runMint(t, "edit", issue1.ID)
+	if err != nil {
+		t.Fatalf("edit command failed: %v", err)
+	}
+	if !strings.Contains(output, "✔︎ Issue updated") {
+		t.Errorf("expected success message, got: %s", output)
+	}
+
+	store, _ = LoadStore(filePath)
+	updated, _ := store.GetIssue(issue1.ID)
+	if updated.Title != "Edited title" {
+		t.Errorf("expected title 'Edited title', got '%s'", updated.Title)
+	}
+	if len(updated.DependsOn) != 1 || updated.DependsOn[0] != issue2.ID {
+		t.Errorf("expected dependency on %s, got %v", issue2.ID, updated.DependsOn)
+	}
+	if blocker, _ := store.GetIssue(issue2.ID); len(blocker.Blocks) != 1 {
+		t.Errorf("expected %s to block the edited issue, got %v", issue2.ID, blocker.Blocks)
+	}
+	if len(updated.Comments) != 1 || updated.Comments[0] != "Added in the editor" {
+		t.Errorf("expected the added comment, got %v", updated.Comments)
+	}
+}
+
+func TestEditCommandReopensOnError(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	issue, _ := store.AddIssue("Original title")
+	_ = store.Save(filePath)
+
+	// The first attempt sets an invalid status; the second sees the error
+	// and fixes it
+	seen := filepath.Join(tmpDir, "seen")
+	setEditor(t, `if grep -q '^# ERROR: invalid status' "$1"; then
+  cp "$1" `+seen+`
+  sed -i.bak 's/^status: .*/status: closed/' "$1"
+else
+  sed -i.bak 's/^status: .*/status: finished/' "$1"
+fi`)
+
+	if _, err := runMint(t, "edit", issue.ID); err != nil {
+		t.Fatalf("edit command failed: %v", err)
+	}
+	if _, err := os.Stat(seen); err != nil {
+		t.Fatal("expected the editor to be reopened with the error")
+	}
+
+	store, _ = LoadStore(filePath)
+	updated, _ := store.GetIssue(issue.ID)
+	if updated.Status != "closed" {
+		t.Errorf("expected status closed, got %s", updated.Status)
+	}
+}
+
+func TestEditCommandGivesUp(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	issue, _ := store.AddIssue("Original title")
+	_ = store.Save(filePath)
+
+	// Saving the rejected document unchanged aborts with the error
+	setEditor(t, `grep -q '^# ERROR' "$1" || sed -i.bak 's/^depends_on: \[\]/depends_on: [nope]/' "$1"`)
+
+	_, err := runMint(t, "edit", issue.ID)
+	if err == nil || !strings.Contains(err.Error(), "depends_on") {
+		t.Fatalf("expected depends_on error, got %v", err)
+	}
+
+	store, _ = LoadStore(filePath)
+	if updated, _ := store.GetIssue(issue.ID); len(updated.DependsOn) != 0 {
+		t.Errorf("expected no dependencies, got %v", updated.DependsOn)
+	}
+}
+
+func TestEditCommandNoChanges(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	issue, _ := store.AddIssue("Original title")
+	_ = store.Save(filePath)
+
+	setEditor(t, "true")
+	output, err := runMint(t, "edit", issue.ID)
+	if err != nil {
+		t.Fatalf("edit command failed: %v", err)
+	}
+	if !strings.Contains(output, "No changes.") {
+		t.Errorf("expected no changes message, got: %s", output)
+	}
+
+	setEditor(t, `: > "$1"`)
+	output, err = runMint(t, "edit", issue.ID)
+	if err != nil {
+		t.Fatalf("edit command failed: %v", err)
+	}
+	if !strings.Contains(output, "Edit cancelled.") {
+		t.Errorf("expected cancelled message, got: %s", output)
+	}
+}
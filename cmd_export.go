@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v3"
+)
+
+func exportBeadsAction(_ context.Context, cmd *cli.Command) error {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	return withExportWriter(cmd, func(w io.Writer) error {
+		return ExportBeads(store, w)
+	})
+}
+
+func exportMarkdownAction(_ context.Context, cmd *cli.Command) error {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	return withExportWriter(cmd, func(w io.Writer) error {
+		return ExportMarkdown(store, w)
+	})
+}
+
+func exportHTMLAction(_ context.Context, cmd *cli.Command) error {
+	outDir := cmd.String("out")
+	if outDir == "" {
+		return fmt.Errorf("output directory is required (--out)")
+	}
+
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err := ExportHTML(store, outDir); err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+	_, err = fmt.Fprintf(w, "\x1b[1;32m✔︎ Exported %d issues to %s\x1b[0m\n", len(store.Issues), filepath.Join(outDir, "index.html"))
+	return err
+}
+
+func exportCSVAction(_ context.Context, cmd *cli.Command) error {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	var columns []string
+	for _, value := range cmd.StringSlice("columns") {
+		columns = append(columns, splitCSVList(value)...)
+	}
+
+	return withExportWriter(cmd, func(w io.Writer) error {
+		return ExportCSV(store, w, columns)
+	})
+}
+
+// withExportWriter calls write with the file named by --out, or with the
+// command's writer when --out isn't set
+func withExportWriter(cmd *cli.Command, write func(w io.Writer) error) error {
+	outPath := cmd.String("out")
+	if outPath == "" {
+		return write(cmd.Root().Writer)
+	}
+
+	// #nosec G304 -- the output file is explicitly chosen by the user
+	f, err := os.OpenFile(outPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportBeadsCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	issue, _ := store.AddIssue("Exported issue")
+	_ = store.Save(filePath)
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "export", "beads"})
+	if err != nil {
+		t.Fatalf("export beads command failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, `"id":"`+issue.ID+`"`) || !strings.Contains(output, `"title":"Exported issue"`) {
+		t.Errorf("expected JSONL record for the issue, got: %s", output)
+	}
+}
+
+func TestExportBeadsCommand_OutFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	_, _ = store.AddIssue("Exported issue")
+	_ = store.Save(filePath)
+
+	outPath := filepath.Join(tmpDir, "issues.jsonl")
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "export", "beads", "--out", outPath})
+	if err != nil {
+		t.Fatalf("export beads command failed: %v", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing on stdout, got: %s", buf.String())
+	}
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	if !strings.Contains(string(data), `"title":"Exported issue"`) {
+		t.Errorf("expected export file to contain the issue, got: %s", data)
+	}
+}
+
+func TestExportMarkdownCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	issue, _ := store.AddIssue("Documented issue")
+	_ = store.Save(filePath)
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "export", "markdown"})
+	if err != nil {
+		t.Fatalf("export markdown command failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "### "+issue.ID+": Documented issue") {
+		t.Errorf("expected markdown for the issue, got: %s", buf.String())
+	}
+}
+
+func TestExportHTMLCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	issue, _ := store.AddIssue("Published issue")
+	_ = store.Save(filePath)
+
+	outDir := filepath.Join(tmpDir, "site")
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "export", "html", "--out", outDir})
+	if err != nil {
+		t.Fatalf("export html command failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(outDir, "issues", issue.ID+".html")); err != nil {
+		t.Errorf("expected issue page to be written: %v", err)
+	}
+	output := stripANSI(buf.String())
+	if !strings.Contains(output, "✔︎ Exported 1 issues to "+filepath.Join(outDir, "index.html")) {
+		t.Errorf("expected export summary, got: %s", output)
+	}
+}
+
+func TestExportHTMLCommand_NoOut(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("MINT_STORE_FILE", filepath.Join(tmpDir, "mint-issues.yaml"))
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "export", "html"})
+	if err == nil {
+		t.Fatal("expected error without --out")
+	}
+}
+
+func TestExportCSVCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	issue, _ := store.AddIssue("Spreadsheet issue")
+	_ = store.Save(filePath)
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "export", "csv", "--columns", "id,status", "--columns", "title"})
+	if err != nil {
+		t.Fatalf("export csv command failed: %v", err)
+	}
+
+	expected := "id,status,title\n" + issue.ID + ",open,Spreadsheet issue\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
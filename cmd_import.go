@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/urfave/cli/v3"
+)
+
+// importResult summarizes what an import changed in the store
+type importResult struct {
+	Created   []*Issue
+	Updated   []*Issue
+	Unchanged int
+	Notes     []string
+	Changes   []string
+}
+
+// attributedComment formats an imported comment with its author
+func attributedComment(author, body string) string {
+	if author == "" {
+		return body
+	}
+	return author + ": " + body
+}
+
+// updateImportedIssue makes issue match an imported record's title, labels,
+// and status, and adds the record's comments it doesn't have yet. Returns
+// whether anything changed.
+func updateImportedIssue(store *Store, issue *Issue, title string, labels, comments []string, closed bool) (bool, error) {
+	changed := false
+	if issue.Title != title {
+		if err := store.UpdateIssueTitle(issue.ID, title); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+
+	if !slices.Equal(issue.Labels, labels) && (len(issue.Labels) > 0 || len(labels) > 0) {
+		issue.Labels = labels
+		store.touch(issue)
+		changed = true
+	}
+
+	for _, comment := range comments {
+		if !slices.Contains(issue.Comments, comment) {
+			if err := store.AddComment(issue.ID, comment); err != nil {
+				return false, err
+			}
+			changed = true
+		}
+	}
+
+	if closed != (issue.Status == "closed") {
+		var err error
+		if closed {
+			// The other tracker already closed it, so open dependencies
+			// can't keep it open here
+			_, err = store.CloseIssues(issue.ID, resolutionDone, "", "", false, true)
+		} else {
+			err = store.ReopenIssue(issue.ID)
+		}
+		if err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+func importGitHubAction(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("file is required")
+	}
+
+	// #nosec G304 -- the import file is explicitly chosen by the user
+	data, err := os.ReadFile(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := openStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	result, err := ImportGitHub(store, data)
+	if err != nil {
+		return err
+	}
+
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
+		return err
+	}
+
+	return printImportResult(cmd.Root().Writer, "GitHub", result, store)
+}
+
+func importBeadsAction(_ context.Context, cmd *cli.Command) error {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	// Default to the Beads file next to the store
+	importPath := cmd.Args().First()
+	if importPath == "" {
+		importPath = filepath.Join(filepath.Dir(filePath), ".beads", "issues.jsonl")
+	}
+
+	// #nosec G304 -- the import file is explicitly chosen by the user
+	f, err := os.Open(importPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	store, err := openStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	result, err := ImportBeads(store, f)
+	if err != nil {
+		return err
+	}
+
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
+		return err
+	}
+
+	return printImportResult(cmd.Root().Writer, "Beads", result, store)
+}
+
+func importCSVAction(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("file is required")
+	}
+
+	// #nosec G304 -- the import file is explicitly chosen by the user
+	f, err := os.Open(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := openStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	result, err := ImportCSV(store, f, cmd.Bool("force"))
+	if err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+	if cmd.Bool("dry-run") {
+		if len(result.Changes) == 0 {
+			_, err := fmt.Fprintln(w, "No changes.")
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "Would apply %d changes:\n\n", len(result.Changes)); err != nil {
+			return err
+		}
+		for _, change := range result.Changes {
+			if _, err := fmt.Fprintf(w, "   %s\n", change); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintln(w)
+		return err
+	}
+
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
+		return err
+	}
+
+	return printImportResult(w, "CSV", result, store)
+}
+
+// printImportResult prints a summary of an import followed by the issues
+// that were created or updated
+func printImportResult(w io.Writer, source string, result *importResult, store *Store) error {
+	total := len(result.Created) + len(result.Updated) + result.Unchanged
+	if _, err := fmt.Fprintf(w, "\x1b[1;32m✔︎ Imported %d issues from %s\x1b[0m (%d created, %d updated, %d unchanged)\n",
+		total, source, len(result.Created), len(result.Updated), result.Unchanged); err != nil {
+		return err
+	}
+
+	maxIDLen := 0
+	for _, issue := range append(result.Created, result.Updated...) {
+		maxIDLen = max(maxIDLen, len(issue.ID))
+	}
+
+	sections := []struct {
+		header string
+		issues []*Issue
+	}{
+		{"Created", result.Created},
+		{"Updated", result.Updated},
+	}
+	for _, section := range sections {
+		if len(section.issues) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "\n\033[1m\033[38;5;5m%s\033[0m\n", section.header); err != nil {
+			return err
+		}
+		if err := printIssueList(w, section.issues, maxIDLen, store); err != nil {
+			return err
+		}
+	}
+	if len(result.Notes) > 0 {
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+		for _, note := range result.Notes {
+			if _, err := fmt.Fprintf(w, "\033[38;5;8m%s\033[0m\n", note); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
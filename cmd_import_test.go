@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImportGitHubCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	exportPath := filepath.Join(tmpDir, "issues.json")
+	if err := os.WriteFile(exportPath, []byte(githubExport), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "import", "github", exportPath})
+	if err != nil {
+		t.Fatalf("import github command failed: %v", err)
+	}
+
+	store, _ := LoadStore(filePath)
+	if len(store.Issues) != 3 {
+		t.Errorf("expected 3 issues, got %d", len(store.Issues))
+	}
+
+	output := stripANSI(buf.String())
+	if !strings.Contains(output, "✔︎ Imported 3 issues from GitHub (3 created, 0 updated, 0 unchanged)") {
+		t.Errorf("expected import summary, got: %s", output)
+	}
+	if !strings.Contains(output, "Write tests") {
+		t.Errorf("expected output to list created issues, got: %s", output)
+	}
+
+	// Importing again is a no-op
+	cmd = newCommand()
+	buf.Reset()
+	cmd.Writer = &buf
+
+	err = cmd.Run(context.Background(), []string{"mint", "import", "github", exportPath})
+	if err != nil {
+		t.Fatalf("second import github command failed: %v", err)
+	}
+
+	output = stripANSI(buf.String())
+	if !strings.Contains(output, "(0 created, 0 updated, 3 unchanged)") {
+		t.Errorf("expected re-import to change nothing, got: %s", output)
+	}
+}
+
+func TestImportGitHubCommand_NoFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("MINT_STORE_FILE", filepath.Join(tmpDir, "mint-issues.yaml"))
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "import", "github"})
+	if err == nil {
+		t.Fatal("expected error when no file is given")
+	}
+	if err.Error() != "file is required" {
+		t.Errorf("expected 'file is required', got '%s'", err.Error())
+	}
+}
+
+func TestImportBeadsCommand_DefaultPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".beads"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".beads", "issues.jsonl"), []byte(beadsExport), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "import", "beads"})
+	if err != nil {
+		t.Fatalf("import beads command failed: %v", err)
+	}
+
+	store, _ := LoadStore(filePath)
+	if len(store.Issues) != 3 {
+		t.Errorf("expected 3 issues, got %d", len(store.Issues))
+	}
+
+	output := stripANSI(buf.String())
+	if !strings.Contains(output, "✔︎ Imported 3 issues from Beads (3 created, 0 updated, 0 unchanged)") {
+		t.Errorf("expected import summary, got: %s", output)
+	}
+	if !strings.Contains(output, "Skipped 1 non-blocking dependencies") {
+		t.Errorf("expected note about skipped dependencies, got: %s", output)
+	}
+}
+
+func TestImportBeadsCommand_MissingFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("MINT_STORE_FILE", filepath.Join(tmpDir, "mint-issues.yaml"))
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "import", "beads", filepath.Join(tmpDir, "missing.jsonl")})
+	if err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+func TestImportCSVCommand_DryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	issue, _ := store.AddIssue("Old title")
+	_ = store.Save(filePath)
+
+	csvPath := filepath.Join(tmpDir, "issues.csv")
+	if err := os.WriteFile(csvPath, []byte("id,title\n"+issue.ID+",New title\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "import", "csv", csvPath, "--dry-run"})
+	if err != nil {
+		t.Fatalf("import csv command failed: %v", err)
+	}
+
+	output := stripANSI(buf.String())
+	if !strings.Contains(output, "Would apply 1 changes:") || !strings.Contains(output, issue.ID+`: title "Old title" -> "New title"`) {
+		t.Errorf("expected dry run to describe the change, got: %s", output)
+	}
+
+	store, _ = LoadStore(filePath)
+	unchanged, _ := store.GetIssue(issue.ID)
+	if unchanged.Title != "Old title" {
+		t.Errorf("expected dry run not to save, got title '%s'", unchanged.Title)
+	}
+
+	// Without --dry-run the change is saved
+	cmd = newCommand()
+	buf.Reset()
+	cmd.Writer = &buf
+
+	err = cmd.Run(context.Background(), []string{"mint", "import", "csv", csvPath})
+	if err != nil {
+		t.Fatalf("import csv command failed: %v", err)
+	}
+
+	store, _ = LoadStore(filePath)
+	updated, _ := store.GetIssue(issue.ID)
+	if updated.Title != "New title" {
+		t.Errorf("expected title to be updated, got '%s'", updated.Title)
+	}
+	if !strings.Contains(stripANSI(buf.String()), "(0 created, 1 updated, 0 unchanged)") {
+		t.Errorf("expected import summary, got: %s", buf.String())
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/urfave/cli/v3"
@@ -23,6 +24,7 @@ func closeAction(_ context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
+	store.aliasNotes = cmd.Root().ErrWriter
 
 	// Resolve partial ID to full ID
 	fullID, err := store.ResolveIssueID(id)
@@ -30,25 +32,59 @@ func closeAction(_ context.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	reason := cmd.String("reason")
-	if err := store.CloseIssue(fullID, reason); err != nil {
-		return err
+	// --of on its own means the issue is a duplicate
+	resolution := cmd.String("as")
+	if cmd.String("of") != "" && !cmd.IsSet("as") {
+		resolution = resolutionDuplicate
 	}
 
-	issue, err := store.GetIssue(fullID)
+	blocked := store.blockedDependents(fullID)
+	closed, err := store.CloseIssues(fullID, resolution, cmd.String("reason"), cmd.String("of"), cmd.Bool("cascade"), cmd.Bool("force"))
 	if err != nil {
 		return err
 	}
+	issue := closed[0]
 
-	if err := store.Save(filePath); err != nil {
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
+	nowReady := store.nowReady(blocked)
+	if cmd.Bool("json") {
+		// The same shape as the HTTP API's close response
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(closeResult(store, closed, nowReady))
+	}
 	if _, err := fmt.Fprintf(w, "\x1b[1;32m✔︎ Issue closed\x1b[0m\n"); err != nil {
 		return err
 	}
-	return PrintIssueDetails(w, issue, store)
+	if err := PrintIssueDetails(w, issue, store); err != nil {
+		return err
+	}
+	if len(closed) > 1 {
+		if _, err := fmt.Fprintln(w, "\033[1m\033[38;5;5mAlso closed\033[0m"); err != nil {
+			return err
+		}
+		for _, dependent := range closed[1:] {
+			if _, err := fmt.Fprintf(w, "  %s %s %s\n", store.FormatID(dependent.ID), statusLabel(dependent), dependent.Title); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	if err := printNowReady(w, nowReady, store); err != nil {
+		return err
+	}
+	if issue.Branch != "" {
+		if _, err := fmt.Fprintf(w, "\033[38;5;8mBranch %s can be deleted with: git branch -d %s\033[0m\n\n", issue.Branch, issue.Branch); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func openAction(_ context.Context, cmd *cli.Command) error {
@@ -67,6 +103,7 @@ func openAction(_ context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
+	store.aliasNotes = cmd.Root().ErrWriter
 
 	// Resolve partial ID to full ID
 	fullID, err := store.ResolveIssueID(id)
@@ -78,7 +115,7 @@ func openAction(_ context.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	if err := store.Save(filePath); err != nil {
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
 		return err
 	}
 
@@ -103,6 +140,7 @@ func deleteAction(_ context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
+	store.aliasNotes = cmd.Root().ErrWriter
 
 	// Resolve partial ID to full ID
 	fullID, err := store.ResolveIssueID(id)
@@ -110,15 +148,36 @@ func deleteAction(_ context.Context, cmd *cli.Command) error {
 		return err
 	}
 
+	var relinked [][2]string
+	if cmd.Bool("cascade") {
+		if relinked, err = store.RelinkDependents(fullID); err != nil {
+			return err
+		}
+	}
+	blocked := store.blockedDependents(fullID)
 	if err := store.DeleteIssue(fullID); err != nil {
 		return err
 	}
 
-	if err := store.Save(filePath); err != nil {
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	_, err = fmt.Fprintf(w, "Deleted issue %s\n", store.FormatID(fullID))
-	return err
+	if _, err := fmt.Fprintf(w, "Deleted issue %s\n", store.FormatID(fullID)); err != nil {
+		return err
+	}
+	for _, link := range relinked {
+		if _, err := fmt.Fprintf(w, "\033[38;5;8m%s now depends on %s\033[0m\n", store.FormatID(link[0]), store.FormatID(link[1])); err != nil {
+			return err
+		}
+	}
+	nowReady := store.nowReady(blocked)
+	if len(nowReady) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	return printNowReady(w, nowReady, store)
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -77,13 +78,8 @@ func TestCloseCommand_WithReason(t *testing.T) {
 		t.Errorf("expected status 'closed', got '%s'", closed.Status)
 	}
 
-	if len(closed.Comments) != 1 {
-		t.Fatalf("expected 1 comment, got %d", len(closed.Comments))
-	}
-
-	expectedComment := "Closed with reason: Done"
-	if closed.Comments[0] != expectedComment {
-		t.Errorf("expected comment '%s', got '%s'", expectedComment, closed.Comments[0])
+	if closed.Resolution != "done" || closed.Reason != "Done" {
+		t.Errorf("expected resolution 'done' with reason 'Done', got '%s' '%s'", closed.Resolution, closed.Reason)
 	}
 
 	output := stripANSI(buf.String())
@@ -96,14 +92,151 @@ func TestCloseCommand_WithReason(t *testing.T) {
 	if !strings.Contains(output, "Title   Test issue") {
 		t.Errorf("expected output to contain 'Title   Test issue', got: %s", output)
 	}
-	if !strings.Contains(output, "Status  closed") {
-		t.Errorf("expected output to contain 'Status  closed', got: %s", output)
+	if !strings.Contains(output, "Status  closed as done\nReason  Done\n") {
+		t.Errorf("expected output to contain the resolution and reason, got: %s", output)
+	}
+	if !strings.Contains(output, "Closed  ") {
+		t.Errorf("expected output to contain the close time, got: %s", output)
+	}
+}
+
+func TestCloseCommand_AsDuplicate(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	original, _ := store.AddIssue("Parser crashes")
+	duplicate, _ := store.AddIssue("Crash in parser")
+	stale, _ := store.AddIssue("Old idea")
+	_ = store.Save(filePath)
+
+	if _, err := runMint(t, "close", duplicate.ID, "--as", "duplicate"); err == nil {
+		t.Fatal("expected a duplicate without --of to be refused")
+	}
+	if _, err := runMint(t, "close", stale.ID, "--as", "stale"); err == nil || !strings.Contains(err.Error(), "invalid resolution") {
+		t.Fatalf("expected an unknown resolution to be refused, got %v", err)
+	}
+
+	// --of on its own closes as a duplicate
+	output, err := runMint(t, "close", duplicate.ID, "--of", original.ID)
+	if err != nil {
+		t.Fatalf("close --of failed: %v", err)
+	}
+	if !strings.Contains(output, "Status  closed as duplicate of "+original.ID) {
+		t.Errorf("expected the original in the status, got: %s", output)
+	}
+	if _, err := runMint(t, "close", stale.ID, "--as", "obsolete"); err != nil {
+		t.Fatalf("close --as failed: %v", err)
+	}
+
+	output, err = runMint(t, "list")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	for _, want := range []string{duplicate.ID + " duplicate Crash in parser", stale.ID + " obsolete Old idea"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected list to contain %q, got: %s", want, output)
+		}
+	}
+}
+
+func TestCloseCommand_NowReady(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	parser, _ := store.AddIssue("Write parser")
+	docs, _ := store.AddIssue("Write docs")
+	release, _ := store.AddIssue("Release")
+	review, _ := store.AddIssue("Review")
+	_ = store.AddDependency(docs.ID, parser.ID)
+	_ = store.AddDependency(release.ID, parser.ID)
+	_ = store.AddDependency(release.ID, review.ID)
+	_ = store.Save(filePath)
+
+	output, err := runMint(t, "close", parser.ID)
+	if err != nil {
+		t.Fatalf("close command failed: %v", err)
+	}
+	if !strings.Contains(output, "Now ready\n  "+docs.ID+" open Write docs\n") {
+		t.Errorf("expected docs to be listed as now ready, got: %s", output)
+	}
+	if _, nowReady, _ := strings.Cut(output, "Now ready"); strings.Contains(nowReady, "Release") {
+		t.Errorf("expected release to stay blocked by the review, got: %s", output)
 	}
-	if !strings.Contains(output, "Comments") {
-		t.Errorf("expected output to contain 'Comments', got: %s", output)
+
+	// Closing an issue that blocks nothing has no section
+	output, err = runMint(t, "close", docs.ID)
+	if err != nil {
+		t.Fatalf("close command failed: %v", err)
 	}
-	if !strings.Contains(output, "Closed with reason: Done") {
-		t.Errorf("expected output to contain 'Closed with reason: Done', got: %s", output)
+	if strings.Contains(output, "Now ready") {
+		t.Errorf("expected no now ready section, got: %s", output)
+	}
+}
+
+func TestCloseCommand_JSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	parser, _ := store.AddIssue("Write parser")
+	docs, _ := store.AddIssue("Write docs")
+	_ = store.AddDependency(docs.ID, parser.ID)
+	_ = store.Save(filePath)
+
+	output, err := runMint(t, "close", parser.ID, "--json")
+	if err != nil {
+		t.Fatalf("close command failed: %v", err)
+	}
+	var result apiCloseResult
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", output, err)
+	}
+	if result.Issue == nil || result.Issue.ID != parser.ID || result.Issue.Status != "closed" {
+		t.Errorf("expected the closed issue, got %+v", result.Issue)
+	}
+	if len(result.NowReady) != 1 || result.NowReady[0].ID != docs.ID {
+		t.Errorf("expected %s to be now ready, got %+v", docs.ID, result.NowReady)
+	}
+}
+
+func TestCloseCommand_OpenDependencies(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	design, _ := store.AddIssue("Design")
+	build, _ := store.AddIssue("Build")
+	ship, _ := store.AddIssue("Ship")
+	_ = store.AddDependency(build.ID, design.ID)
+	_ = store.AddDependency(ship.ID, build.ID)
+	_ = store.Save(filePath)
+
+	if _, err := runMint(t, "close", build.ID); err == nil || !strings.Contains(err.Error(), "depends on open issues") {
+		t.Fatalf("expected close to be refused, got %v", err)
+	}
+	store, _ = LoadStore(filePath)
+	if store.Issues[build.ID].Status != "open" {
+		t.Fatalf("expected the refused close not to be saved")
+	}
+
+	output, err := runMint(t, "close", design.ID, "--cascade")
+	if err != nil {
+		t.Fatalf("close --cascade failed: %v", err)
+	}
+	if !strings.Contains(output, "Also closed\n  "+build.ID+" closed Build\n  "+ship.ID+" closed Ship\n") {
+		t.Errorf("expected the dependents to be listed, got: %s", output)
+	}
+	store, _ = LoadStore(filePath)
+	for _, issue := range store.Issues {
+		if issue.Status != "closed" {
+			t.Errorf("expected %s to be closed", issue.ID)
+		}
 	}
 }
 
@@ -369,6 +502,55 @@ func TestDeleteCommand(t *testing.T) {
 	}
 }
 
+func TestDeleteCommand_NowReady(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	spike, _ := store.AddIssue("Spike")
+	build, _ := store.AddIssue("Build it")
+	_ = store.AddDependency(build.ID, spike.ID)
+	_ = store.Save(filePath)
+
+	output, err := runMint(t, "delete", spike.ID)
+	if err != nil {
+		t.Fatalf("delete command failed: %v", err)
+	}
+	if !strings.Contains(output, "Deleted issue "+spike.ID+"\n\nNow ready\n  "+build.ID+" open Build it\n") {
+		t.Errorf("expected the dependent to be listed as now ready, got: %s", output)
+	}
+}
+
+func TestDeleteCommand_Cascade(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	design, _ := store.AddIssue("Design")
+	spike, _ := store.AddIssue("Spike")
+	build, _ := store.AddIssue("Build")
+	_ = store.AddDependency(spike.ID, design.ID)
+	_ = store.AddDependency(build.ID, spike.ID)
+	_ = store.Save(filePath)
+
+	output, err := runMint(t, "delete", spike.ID, "--cascade")
+	if err != nil {
+		t.Fatalf("delete --cascade failed: %v", err)
+	}
+	if !strings.Contains(output, build.ID+" now depends on "+design.ID) {
+		t.Errorf("expected the relink to be reported, got: %s", output)
+	}
+	if strings.Contains(output, "Now ready") {
+		t.Errorf("expected build to stay blocked by design, got: %s", output)
+	}
+	store, _ = LoadStore(filePath)
+	if deps := store.Issues[build.ID].DependsOn; len(deps) != 1 || deps[0] != design.ID {
+		t.Errorf("expected build to depend on design, got %v", deps)
+	}
+}
+
 func TestDeleteCommand_NoID(t *testing.T) {
 	tmpDir := t.TempDir()
 	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
@@ -3,13 +3,16 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/urfave/cli/v3"
 )
 
-func listAction(_ context.Context, cmd *cli.Command) error {
+func listAction(ctx context.Context, cmd *cli.Command) error {
 	filePath, err := GetStoreFilePath()
 	if err != nil {
 		return err
@@ -17,6 +20,34 @@ func listAction(_ context.Context, cmd *cli.Command) error {
 
 	w := cmd.Root().Writer
 
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Flags take precedence over the configured defaults
+	opts := listOptions{
+		order:     cfg.String("list.sort"),
+		openOnly:  cmd.Bool("open"),
+		readyOnly: cmd.Bool("ready"),
+		limit:     cfg.Int("list.limit"),
+	}
+	if cmd.IsSet("sort") {
+		opts.order = cmd.String("sort")
+		if !slices.Contains(listSortOrders, opts.order) {
+			return fmt.Errorf("invalid sort order %q (must be one of %s)", opts.order, strings.Join(listSortOrders, ", "))
+		}
+	}
+	if cmd.IsSet("limit") {
+		opts.limit = cmd.Int("limit")
+	}
+
+	if cmd.Bool("watch") {
+		return watchStore(ctx, w, filePath, func(w io.Writer, store *Store, changed map[string]bool) error {
+			return printList(w, store, opts, changed)
+		})
+	}
+
 	// Check if file exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		_, err := fmt.Fprintln(w, "No issues file found.")
@@ -27,7 +58,20 @@ func listAction(_ context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
+	return printList(w, store, opts, nil)
+}
 
+// listOptions are the settings list applies to its sections
+type listOptions struct {
+	order     string
+	openOnly  bool
+	readyOnly bool
+	limit     int
+}
+
+// printList prints the store's issues in ready, blocked, and closed
+// sections, marking those in changed
+func printList(w io.Writer, store *Store, opts listOptions, changed map[string]bool) error {
 	issues := store.ListIssues()
 
 	// Handle completely empty store
@@ -38,36 +82,18 @@ func listAction(_ context.Context, cmd *cli.Command) error {
 
 	// Calculate max ID length and separate issues into ready, blocked, and closed
 	maxIDLen := 0
-	readyIssues := make([]*Issue, 0)
-	blockedIssues := make([]*Issue, 0)
-	closedIssues := make([]*Issue, 0)
 	for _, issue := range issues {
 		if len(issue.ID) > maxIDLen {
 			maxIDLen = len(issue.ID)
 		}
-		if issue.Status == "open" {
-			if store.IsReady(issue) {
-				readyIssues = append(readyIssues, issue)
-			} else {
-				blockedIssues = append(blockedIssues, issue)
-			}
-		} else {
-			closedIssues = append(closedIssues, issue)
-		}
 	}
+	readyIssues, blockedIssues, closedIssues := partitionIssues(store)
 
-	openOnly := cmd.Bool("open")
-	readyOnly := cmd.Bool("ready")
-	limit := cmd.Int("limit")
+	sortIssues(readyIssues, opts.order)
+	sortIssues(blockedIssues, opts.order)
+	sortIssues(closedIssues, opts.order)
 
-	// Sort issues by timestamps (only what we'll display)
-	sortByCreatedAt(readyIssues)
-	if !readyOnly {
-		sortByCreatedAt(blockedIssues)
-	}
-	if !openOnly && !readyOnly {
-		sortByUpdatedAt(closedIssues)
-	}
+	openOnly, readyOnly, limit := opts.openOnly, opts.readyOnly, opts.limit
 
 	// Track original counts before applying limit
 	readyTotalCount := len(readyIssues)
@@ -108,7 +134,7 @@ func listAction(_ context.Context, cmd *cli.Command) error {
 			return err
 		}
 	} else {
-		if err := printIssueList(w, readyIssues, maxIDLen, store); err != nil {
+		if err := printChangedIssueList(w, readyIssues, maxIDLen, store, changed); err != nil {
 			return err
 		}
 	}
@@ -136,7 +162,7 @@ func listAction(_ context.Context, cmd *cli.Command) error {
 				return err
 			}
 		} else {
-			if err := printIssueList(w, blockedIssues, maxIDLen, store); err != nil {
+			if err := printChangedIssueList(w, blockedIssues, maxIDLen, store, changed); err != nil {
 				return err
 			}
 		}
@@ -165,7 +191,7 @@ func listAction(_ context.Context, cmd *cli.Command) error {
 				return err
 			}
 		} else {
-			if err := printIssueList(w, closedIssues, maxIDLen, store); err != nil {
+			if err := printChangedIssueList(w, closedIssues, maxIDLen, store, changed); err != nil {
 				return err
 			}
 		}
@@ -178,6 +204,31 @@ func listAction(_ context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+// partitionIssues separates the store's issues into ready, blocked, and
+// closed sections, sorted the way list displays them
+func partitionIssues(store *Store) (ready, blocked, closed []*Issue) {
+	ready = make([]*Issue, 0)
+	blocked = make([]*Issue, 0)
+	closed = make([]*Issue, 0)
+	for _, issue := range store.ListIssues() {
+		if issue.Status == "open" {
+			if store.IsReady(issue) {
+				ready = append(ready, issue)
+			} else {
+				blocked = append(blocked, issue)
+			}
+		} else {
+			closed = append(closed, issue)
+		}
+	}
+
+	// Sort issues by timestamps
+	sortByCreatedAt(ready)
+	sortByCreatedAt(blocked)
+	sortByUpdatedAt(closed)
+	return ready, blocked, closed
+}
+
 func sortByCreatedAt(issues []*Issue) {
 	sort.Slice(issues, func(i, j int) bool {
 		return issues[i].CreatedAt.After(issues[j].CreatedAt)
@@ -189,3 +240,25 @@ func sortByUpdatedAt(issues []*Issue) {
 		return issues[i].UpdatedAt.After(issues[j].UpdatedAt)
 	})
 }
+
+// listSortOrders are the orders list --sort accepts
+var listSortOrders = []string{"default", "created", "updated", "id", "title"}
+
+// sortIssues re-sorts a section of list; "default" keeps the order from
+// partitionIssues
+func sortIssues(issues []*Issue, order string) {
+	switch order {
+	case "created":
+		sortByCreatedAt(issues)
+	case "updated":
+		sortByUpdatedAt(issues)
+	case "id":
+		sort.Slice(issues, func(i, j int) bool {
+			return issues[i].ID < issues[j].ID
+		})
+	case "title":
+		sort.SliceStable(issues, func(i, j int) bool {
+			return strings.ToLower(issues[i].Title) < strings.ToLower(issues[j].Title)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+)
+
+func mcpAction(ctx context.Context, cmd *cli.Command) error {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+	return newMCPServer(filePath).Serve(ctx, cmd.Root().Reader, cmd.Root().Writer)
+}
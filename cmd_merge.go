@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+func mergeAction(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() < 2 {
+		return fmt.Errorf("the IDs of the issue to keep and its duplicate are required")
+	}
+
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+	store.aliasNotes = cmd.Root().ErrWriter
+
+	keepID, err := store.ResolveIssueID(cmd.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	dupID, err := store.ResolveIssueID(cmd.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	if err := store.MergeIssue(keepID, dupID); err != nil {
+		return err
+	}
+
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+	if _, err := fmt.Fprintf(w, "\x1b[1;32m✔︎ Merged %s into %s\x1b[0m\n", dupID, keepID); err != nil {
+		return err
+	}
+	return PrintIssueDetails(w, store.Issues[keepID], store)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	keep, _ := store.AddIssue("Parser crashes")
+	dup, _ := store.AddIssue("Crash in parser")
+	release, _ := store.AddIssue("Release")
+	_ = store.AddComment(dup.ID, "Stack trace attached")
+	_ = store.AddDependency(release.ID, dup.ID)
+	_ = store.Save(filePath)
+
+	output, err := runMint(t, "merge", keep.ID, dup.ID)
+	if err != nil {
+		t.Fatalf("merge command failed: %v", err)
+	}
+	if !strings.Contains(output, "✔︎ Merged "+dup.ID+" into "+keep.ID) {
+		t.Errorf("expected a success message, got: %s", output)
+	}
+	if !strings.Contains(output, "Stack trace attached") {
+		t.Errorf("expected the kept issue with the moved comment, got: %s", output)
+	}
+
+	store, _ = LoadStore(filePath)
+	if len(store.Issues) != 2 || store.Aliases[dup.ID] != keep.ID {
+		t.Fatalf("expected the duplicate to be replaced by an alias, got %v and %v", store.Issues, store.Aliases)
+	}
+	if deps := store.Issues[release.ID].DependsOn; len(deps) != 1 || deps[0] != keep.ID {
+		t.Errorf("expected the release to depend on the kept issue, got %v", deps)
+	}
+
+	// The old ID keeps working
+	output, err = runMint(t, "show", dup.ID)
+	if err != nil {
+		t.Fatalf("show by the old ID failed: %v", err)
+	}
+	if !strings.Contains(output, "Title   Parser crashes") {
+		t.Errorf("expected the kept issue, got: %s", output)
+	}
+}
+
+func TestMergeCommand_MissingID(t *testing.T) {
+	t.Setenv("MINT_STORE_FILE", filepath.Join(t.TempDir(), "mint-issues.yaml"))
+
+	if _, err := runMint(t, "merge", "mint-abc"); err == nil {
+		t.Fatal("expected an error when the duplicate is missing")
+	}
+}
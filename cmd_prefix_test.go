@@ -371,8 +371,11 @@ func TestSetPrefixCommand_PreservesIssueProperties(t *testing.T) {
 	if newIssue.Status != "closed" {
 		t.Errorf("expected status 'closed', got '%s'", newIssue.Status)
 	}
-	if len(newIssue.Comments) != 3 {
-		t.Errorf("expected 3 comments, got %d", len(newIssue.Comments))
+	if len(newIssue.Comments) != 2 {
+		t.Errorf("expected 2 comments, got %d", len(newIssue.Comments))
+	}
+	if newIssue.Reason != "Done" {
+		t.Errorf("expected reason preserved, got '%s'", newIssue.Reason)
 	}
 	if newIssue.Comments[0] != "First comment" {
 		t.Errorf("expected first comment preserved, got '%s'", newIssue.Comments[0])
@@ -551,3 +554,23 @@ func TestSetPrefixCommand_FromEmptyToNonEmpty(t *testing.T) {
 		t.Error("expected 'app-abc123' to exist")
 	}
 }
+
+func TestSetPrefixCommand_KeepsOldIDs(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	issue, _ := store.AddIssue("Write parser")
+	_ = store.Save(filePath)
+
+	if _, err := runMint(t, "set-prefix", "am"); err != nil {
+		t.Fatalf("set-prefix failed: %v", err)
+	}
+	output, err := runMint(t, "show", issue.ID)
+	if err != nil {
+		t.Fatalf("show by the old ID failed: %v", err)
+	}
+	if !strings.Contains(output, "ID      am-") {
+		t.Errorf("expected the renamed issue, got: %s", output)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+func scanAction(_ context.Context, cmd *cli.Command) error {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := openStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	// Paths are given relative to the current directory but tracked relative
+	// to the project root, so scans from anywhere agree
+	root, err := projectRoot()
+	if err != nil {
+		return err
+	}
+	paths := cmd.Args().Slice()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	scope := make([]string, 0, len(paths))
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("path %s is outside %s", p, root)
+		}
+		scope = append(scope, filepath.ToSlash(rel))
+	}
+
+	files, err := listScanFiles(root, scope)
+	if err != nil {
+		return err
+	}
+
+	markers, err := findTodoMarkers(root, files)
+	if err != nil {
+		return err
+	}
+
+	result, err := ApplyTodoScan(store, markers, scope)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+	dryRun := cmd.Bool("dry-run")
+	if !dryRun {
+		if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
+			return err
+		}
+	}
+
+	verb := "Scanned"
+	if dryRun {
+		verb = "Dry run: scanned"
+	}
+	if _, err := fmt.Fprintf(w, "\x1b[1;32m✔︎ %s %d files\x1b[0m (%d markers, %d new issues, %d closed)\n",
+		verb, len(files), len(markers), len(result.Created), len(result.Closed)); err != nil {
+		return err
+	}
+
+	maxIDLen := 0
+	for _, issue := range append(result.Created, result.Closed...) {
+		maxIDLen = max(maxIDLen, len(issue.ID))
+	}
+	sections := []struct {
+		header string
+		issues []*Issue
+	}{
+		{"Created", result.Created},
+		{"Closed", result.Closed},
+	}
+	for _, section := range sections {
+		if len(section.issues) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "\n\033[1m\033[38;5;5m%s\033[0m\n", section.header); err != nil {
+			return err
+		}
+		if err := printIssueList(w, section.issues, maxIDLen, store); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Bool("write-ids") && !dryRun {
+		written, err := writeTodoIDs(root, store, result.Unwritten)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "\nWrote %d issue IDs into markers\n", written); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintln(w)
+	return err
+}
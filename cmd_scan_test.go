@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScanCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	t.Chdir(tmpDir)
+
+	writeScanFile(t, tmpDir, "main.go", "package main\n\n// TODO: handle errors\n")
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "scan", "--write-ids"})
+	if err != nil {
+		t.Fatalf("scan command failed: %v", err)
+	}
+
+	store, _ := LoadStore(filePath)
+	if len(store.Issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d", len(store.Issues))
+	}
+	issue := store.ListIssues()[0]
+
+	output := stripANSI(buf.String())
+	if !strings.Contains(output, "✔︎ Scanned 1 files (1 markers, 1 new issues, 0 closed)") {
+		t.Errorf("expected scan summary, got: %s", output)
+	}
+	if !strings.Contains(output, "Wrote 1 issue IDs into markers") {
+		t.Errorf("expected write-back summary, got: %s", output)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(tmpDir, "main.go"))
+	if !strings.Contains(string(data), "// TODO("+issue.ID+"): handle errors") {
+		t.Errorf("expected issue ID in marker, got: %s", data)
+	}
+
+	// Removing the marker closes the issue
+	writeScanFile(t, tmpDir, "main.go", "package main\n")
+	cmd = newCommand()
+	buf.Reset()
+	cmd.Writer = &buf
+
+	err = cmd.Run(context.Background(), []string{"mint", "scan"})
+	if err != nil {
+		t.Fatalf("scan command failed: %v", err)
+	}
+
+	store, _ = LoadStore(filePath)
+	closed, _ := store.GetIssue(issue.ID)
+	if closed.Status != "closed" {
+		t.Errorf("expected issue to be closed, got '%s'", closed.Status)
+	}
+}
+
+func TestScanCommand_DryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	t.Chdir(tmpDir)
+
+	writeScanFile(t, tmpDir, "src/main.go", "package main\n\n// TODO: handle errors\n")
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "scan", "src", "--dry-run", "--write-ids"})
+	if err != nil {
+		t.Fatalf("scan command failed: %v", err)
+	}
+
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Error("expected dry run not to create the store")
+	}
+	data, _ := os.ReadFile(filepath.Join(tmpDir, "src", "main.go"))
+	if strings.Contains(string(data), "TODO(") {
+		t.Error("expected dry run not to write IDs back")
+	}
+	if !strings.Contains(stripANSI(buf.String()), "Dry run: scanned 1 files") {
+		t.Errorf("expected dry run summary, got: %s", buf.String())
+	}
+}
+
+func TestScanCommand_PathOutsideRoot(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("MINT_STORE_FILE", filepath.Join(tmpDir, "store", "mint-issues.yaml"))
+	t.Chdir(tmpDir)
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+
+	err := cmd.Run(context.Background(), []string{"mint", "scan", "."})
+	if err == nil || !strings.Contains(err.Error(), "is outside") {
+		t.Errorf("expected outside-root error, got: %v", err)
+	}
+}
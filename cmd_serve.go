@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+func serveAction(ctx context.Context, cmd *cli.Command) error {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", cmd.String("addr"))
+	if err != nil {
+		return err
+	}
+	host, _, err := net.SplitHostPort(cmd.String("addr"))
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Handler:           newAPIServer(filePath, host).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	if _, err := fmt.Fprintf(cmd.Root().Writer, "Serving %s on http://%s\n", filePath, listener.Addr()); err != nil {
+		return err
+	}
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
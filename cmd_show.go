@@ -3,11 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/urfave/cli/v3"
 )
 
-func showAction(_ context.Context, cmd *cli.Command) error {
+func showAction(ctx context.Context, cmd *cli.Command) error {
 	if cmd.Args().Len() == 0 {
 		return fmt.Errorf("issue ID is required")
 	}
@@ -19,10 +20,21 @@ func showAction(_ context.Context, cmd *cli.Command) error {
 		return err
 	}
 
+	if cmd.Bool("watch") {
+		return watchStore(ctx, cmd.Root().Writer, filePath, func(w io.Writer, store *Store, changed map[string]bool) error {
+			issue, err := store.GetIssue(id)
+			if err != nil {
+				return err
+			}
+			return printIssueDetails(w, issue, store, changed)
+		})
+	}
+
 	store, err := LoadStore(filePath)
 	if err != nil {
 		return err
 	}
+	store.aliasNotes = cmd.Root().ErrWriter
 
 	issue, err := store.GetIssue(id)
 	if err != nil {
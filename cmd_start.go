@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+func startAction(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("issue ID is required")
+	}
+
+	id := cmd.Args().First()
+
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+	store.aliasNotes = cmd.Root().ErrWriter
+
+	// Resolve partial ID to full ID
+	fullID, err := store.ResolveIssueID(id)
+	if err != nil {
+		return err
+	}
+
+	issue, err := store.GetIssue(fullID)
+	if err != nil {
+		return err
+	}
+	if issue.Status == "closed" {
+		return fmt.Errorf("issue %s is closed", fullID)
+	}
+
+	// Reuse the branch recorded by an earlier start. Git runs in the current
+	// checkout, which for a linked worktree isn't where the store is.
+	dir, err := projectRoot()
+	if err != nil {
+		return err
+	}
+	branch := issue.Branch
+	if branch == "" {
+		branch = branchName(issue)
+	}
+	if branchExists(dir, branch) {
+		_, err = runGit(dir, "checkout", "--quiet", branch)
+	} else {
+		_, err = runGit(dir, "checkout", "--quiet", "-b", branch)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The branch may have its own version of the store
+	if store, err = LoadStore(filePath); err != nil {
+		return err
+	}
+	store.aliasNotes = cmd.Root().ErrWriter
+	issue = store.Issues[fullID]
+	if issue == nil {
+		return fmt.Errorf("issue %s is not in the store on branch %s", fullID, branch)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	owner := cfg.String("author")
+	if owner == "" {
+		owner = currentUser(dir)
+	}
+
+	if err := store.StartIssue(fullID, owner, branch); err != nil {
+		return err
+	}
+
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+	if _, err := fmt.Fprintf(w, "\x1b[1;32m✔︎ Started issue on branch %s\x1b[0m\n", branch); err != nil {
+		return err
+	}
+	return PrintIssueDetails(w, issue, store)
+}
+
+func statusAction(_ context.Context, cmd *cli.Command) error {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	root, err := projectRoot()
+	if err != nil {
+		return err
+	}
+	branch, err := currentBranch(root)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+	if branch == "" {
+		_, err := fmt.Fprintf(w, "Not on a branch\n")
+		return err
+	}
+
+	issue := store.FindIssueByBranch(branch)
+	if issue == nil {
+		_, err := fmt.Fprintf(w, "On branch %s \033[38;5;8m(no associated issue)\033[0m\n", branch)
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "On branch %s\n", branch); err != nil {
+		return err
+	}
+	return PrintIssueDetails(w, issue, store)
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStartAndStatusCommands(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	initGitRepo(t, tmpDir)
+	if _, err := runGit(tmpDir, "config", "user.name", "Alice"); err != nil {
+		t.Fatal(err)
+	}
+	gitCommitMessage(t, tmpDir, "Initial commit")
+
+	store := NewStore()
+	issue, _ := store.AddIssue("Fix parser crash")
+	if err := store.Save(filePath); err != nil {
+		t.Fatal(err)
+	}
+	wantBranch := issue.ID + "-fix-parser-crash"
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+	if err := cmd.Run(context.Background(), []string{"mint", "start", issue.ID}); err != nil {
+		t.Fatalf("start command failed: %v", err)
+	}
+
+	output := stripANSI(buf.String())
+	if !strings.Contains(output, "✔︎ Started issue on branch "+wantBranch) {
+		t.Errorf("expected start message, got: %s", output)
+	}
+	if branch, _ := currentBranch(tmpDir); branch != wantBranch {
+		t.Errorf("expected branch %s to be checked out, got %s", wantBranch, branch)
+	}
+	store, _ = LoadStore(filePath)
+	started, _ := store.GetIssue(issue.ID)
+	if started.Owner != "Alice" || started.Branch != wantBranch {
+		t.Errorf("expected owner Alice and branch %s, got %q and %q", wantBranch, started.Owner, started.Branch)
+	}
+
+	cmd = newCommand()
+	buf.Reset()
+	cmd.Writer = &buf
+	if err := cmd.Run(context.Background(), []string{"mint", "status"}); err != nil {
+		t.Fatalf("status command failed: %v", err)
+	}
+	output = stripANSI(buf.String())
+	if !strings.Contains(output, "On branch "+wantBranch) || !strings.Contains(output, "Fix parser crash") {
+		t.Errorf("expected status to show the issue, got: %s", output)
+	}
+
+	// Starting again from another branch returns to the recorded branch
+	if _, err := runGit(tmpDir, "checkout", "-q", "-b", "other"); err != nil {
+		t.Fatal(err)
+	}
+	cmd = newCommand()
+	buf.Reset()
+	cmd.Writer = &buf
+	if err := cmd.Run(context.Background(), []string{"mint", "start", issue.ID}); err != nil {
+		t.Fatalf("second start command failed: %v", err)
+	}
+	if branch, _ := currentBranch(tmpDir); branch != wantBranch {
+		t.Errorf("expected branch %s to be checked out again, got %s", wantBranch, branch)
+	}
+
+	cmd = newCommand()
+	buf.Reset()
+	cmd.Writer = &buf
+	if err := cmd.Run(context.Background(), []string{"mint", "close", issue.ID}); err != nil {
+		t.Fatalf("close command failed: %v", err)
+	}
+	output = stripANSI(buf.String())
+	if !strings.Contains(output, "git branch -d "+wantBranch) {
+		t.Errorf("expected branch cleanup hint, got: %s", output)
+	}
+}
+
+func TestStartCommand_KeepsBranchStore(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	initGitRepo(t, tmpDir)
+	if _, err := runGit(tmpDir, "checkout", "-q", "-b", "main"); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewStore()
+	issue, _ := store.AddIssue("Fix parser crash")
+	if err := store.Save(filePath); err != nil {
+		t.Fatal(err)
+	}
+	commitStore := func(message string) {
+		t.Helper()
+		if _, err := runGit(tmpDir, "add", "mint-issues.yaml"); err != nil {
+			t.Fatal(err)
+		}
+		gitCommitMessage(t, tmpDir, message)
+	}
+	commitStore("Add issue")
+
+	if _, err := runMint(t, "start", issue.ID); err != nil {
+		t.Fatalf("start command failed: %v", err)
+	}
+	commitStore("Start issue")
+
+	// An issue filed on the branch only
+	store, _ = LoadStore(filePath)
+	onBranch, _ := store.AddIssue("Found while fixing")
+	if err := store.Save(filePath); err != nil {
+		t.Fatal(err)
+	}
+	commitStore("File follow-up")
+
+	if _, err := runGit(tmpDir, "checkout", "-q", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runMint(t, "start", issue.ID); err != nil {
+		t.Fatalf("second start command failed: %v", err)
+	}
+
+	store, _ = LoadStore(filePath)
+	if _, exists := store.Issues[onBranch.ID]; !exists {
+		t.Error("expected the branch's store not to be overwritten")
+	}
+}
+
+func TestStatusCommand_NoIssue(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("MINT_STORE_FILE", filepath.Join(tmpDir, "mint-issues.yaml"))
+	initGitRepo(t, tmpDir)
+	if _, err := runGit(tmpDir, "checkout", "-q", "-b", "main"); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+	if err := cmd.Run(context.Background(), []string{"mint", "status"}); err != nil {
+		t.Fatalf("status command failed: %v", err)
+	}
+	output := stripANSI(buf.String())
+	if !strings.Contains(output, "On branch main (no associated issue)") {
+		t.Errorf("expected no-issue message, got: %s", output)
+	}
+}
+
+func TestStartCommand_ClosedIssue(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	initGitRepo(t, tmpDir)
+
+	store := NewStore()
+	issue, _ := store.AddIssue("Done already")
+	_ = store.CloseIssue(issue.ID, "")
+	if err := store.Save(filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newCommand()
+	err := cmd.Run(context.Background(), []string{"mint", "start", issue.ID})
+	if err == nil || !strings.Contains(err.Error(), "is closed") {
+		t.Errorf("expected closed issue error, got %v", err)
+	}
+}
+
+func TestStartCommand_LinkedWorktree(t *testing.T) {
+	tmpDir := resolvedTempDir(t)
+	mainDir := filepath.Join(tmpDir, "main")
+	if err := os.Mkdir(mainDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	initGitRepo(t, mainDir)
+	gitCommitMessage(t, mainDir, "Initial commit")
+	mainBranch, _ := currentBranch(mainDir)
+	worktreeDir := filepath.Join(tmpDir, "feature")
+	if _, err := runGit(mainDir, "worktree", "add", "-q", "-b", "feature", worktreeDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// The worktree has no store of its own, so it shares the main one
+	mainStore := filepath.Join(mainDir, "mint-issues.yaml")
+	store := NewStore()
+	issue, _ := store.AddIssue("Fix parser crash")
+	if err := store.Save(mainStore); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MINT_STORE_FILE", "")
+	t.Chdir(worktreeDir)
+
+	if _, err := runMint(t, "start", issue.ID); err != nil {
+		t.Fatalf("start command failed: %v", err)
+	}
+	wantBranch := issue.ID + "-fix-parser-crash"
+	if branch, _ := currentBranch(worktreeDir); branch != wantBranch {
+		t.Errorf("expected the worktree to be on %s, got %s", wantBranch, branch)
+	}
+	if branch, _ := currentBranch(mainDir); branch != mainBranch {
+		t.Errorf("expected the main worktree to stay on %s, got %s", mainBranch, branch)
+	}
+	store, _ = LoadStore(mainStore)
+	if store.Issues[issue.ID].Branch != wantBranch {
+		t.Errorf("expected the shared store to record the branch, got %q", store.Issues[issue.ID].Branch)
+	}
+}
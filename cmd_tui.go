@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v3"
+)
+
+func tuiAction(_ context.Context, _ *cli.Command) error {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return err
+	}
+
+	store, err := openStore(filePath)
+	if err != nil {
+		return err
+	}
+
+	// The UI draws straight to the terminal, bypassing the color settings
+	in, out := os.Stdin, os.Stdout
+	if !isTerminal(in) || !isTerminal(out) {
+		return fmt.Errorf("tui requires a terminal")
+	}
+	restore, err := makeRaw(int(in.Fd()))
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	// Use the alternate screen and hide the cursor while running
+	fmt.Fprint(out, "\033[?1049h\033[?25l")
+	defer fmt.Fprint(out, "\033[?25h\033[?1049l")
+
+	resized, stop := notifyResize()
+	defer stop()
+
+	input := make(chan []byte)
+	go func() {
+		buf := make([]byte, 256)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				close(input)
+				return
+			}
+			input <- append([]byte(nil), buf[:n]...)
+		}
+	}()
+
+	// Hook output would draw over the UI, so only a rejected close is shown.
+	// A change that wasn't saved is dropped by reloading the store.
+	ui := newTUI(store, func() error {
+		err := saveStore(store, filePath, io.Discard)
+		if err != nil {
+			if saved, loadErr := openStore(filePath); loadErr == nil {
+				*store = *saved
+			}
+		}
+		return err
+	})
+	for !ui.quit {
+		width, height, err := terminalSize(int(out.Fd()))
+		if err != nil || width == 0 || height == 0 {
+			width, height = 80, 24
+		}
+		if _, err := fmt.Fprint(out, ui.view(width, height)); err != nil {
+			return err
+		}
+
+		select {
+		case b, ok := <-input:
+			if !ok {
+				return nil
+			}
+			for _, key := range parseKeys(b) {
+				ui.handleKey(key)
+			}
+		case <-resized:
+		}
+	}
+	return nil
+}
@@ -23,6 +23,7 @@ func updateAction(_ context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
+	store.aliasNotes = cmd.Root().ErrWriter
 
 	// Resolve partial ID to full ID
 	fullID, err := store.ResolveIssueID(id)
@@ -80,7 +81,7 @@ func updateAction(_ context.Context, cmd *cli.Command) error {
 		}
 	}
 
-	if err := store.Save(filePath); err != nil {
+	if err := saveStore(store, filePath, cmd.Root().ErrWriter); err != nil {
 		return err
 	}
 
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/urfave/cli/v3"
+)
+
+func whereAction(_ context.Context, cmd *cli.Command) error {
+	location, err := LocateStore()
+	if err != nil {
+		return err
+	}
+
+	note := location.Reason
+	if _, err := os.Stat(location.Path); errors.Is(err, fs.ErrNotExist) {
+		note += " (file does not exist yet)"
+	}
+
+	_, err = fmt.Fprintf(cmd.Root().Writer, "%s\n\033[38;5;8m%s\033[0m\n", location.Path, note)
+	return err
+}
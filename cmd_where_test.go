@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWhereCommand(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	cmd := newCommand()
+	var buf bytes.Buffer
+	cmd.Writer = &buf
+	if err := cmd.Run(context.Background(), []string{"mint", "where"}); err != nil {
+		t.Fatalf("where command failed: %v", err)
+	}
+
+	output := stripANSI(buf.String())
+	if !strings.HasPrefix(output, filePath+"\n") {
+		t.Errorf("expected output to start with the store path, got: %s", output)
+	}
+	if !strings.Contains(output, "MINT_STORE_FILE is set (file does not exist yet)") {
+		t.Errorf("expected reason, got: %s", output)
+	}
+}
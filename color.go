@@ -0,0 +1,67 @@
+package main
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ansiPattern matches the escape sequences mint writes
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// colorWriter applies the color settings to output written with the
+// default colors. Every escape sequence mint writes is complete within a
+// single Write, so sequences are never split across calls.
+type colorWriter struct {
+	w        io.Writer
+	strip    bool
+	replacer *strings.Replacer
+}
+
+func (c *colorWriter) Write(p []byte) (int, error) {
+	s := string(p)
+	if c.strip {
+		s = ansiPattern.ReplaceAllString(s, "")
+	} else {
+		s = c.replacer.Replace(s)
+	}
+	if _, err := io.WriteString(c.w, s); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// applyColorConfig wraps w according to the color and theme settings
+// NO_COLOR disables colors unless color is configured explicitly
+func applyColorConfig(w io.Writer, cfg *Config) io.Writer {
+	mode := cfg.String("color")
+	if cfg.Source("color") == "default" && os.Getenv("NO_COLOR") != "" {
+		mode = "never"
+	}
+	if mode == "auto" && !isTerminal(w) {
+		mode = "never"
+	}
+	if mode == "never" {
+		return &colorWriter{w: w, strip: true}
+	}
+
+	accent, muted := cfg.String("theme.accent"), cfg.String("theme.muted")
+	if accent == "5" && muted == "8" {
+		return w
+	}
+	return &colorWriter{w: w, replacer: strings.NewReplacer(
+		"\x1b[38;5;5m", "\x1b[38;5;"+accent+"m",
+		"\x1b[38;5;8m", "\x1b[38;5;"+muted+"m",
+	)}
+}
+
+// isTerminal reports whether w is a terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// gitCommit is a commit read from git log
+type gitCommit struct {
+	Hash    string
+	Subject string
+	Body    string
+}
+
+// Short returns the abbreviated commit hash
+func (c gitCommit) Short() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// readCommits returns the commits reachable from HEAD but not from since
+// (or all commits if since is empty), oldest first
+func readCommits(dir, since string) ([]gitCommit, error) {
+	revRange := "HEAD"
+	if since != "" {
+		revRange = since + "..HEAD"
+	}
+	out, err := runGit(dir, "log", "--reverse", "--format=%H%x1f%s%x1f%B%x1e", revRange)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []gitCommit
+	for record := range strings.SplitSeq(out, "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		commits = append(commits, gitCommit{Hash: fields[0], Subject: fields[1], Body: fields[2]})
+	}
+	return commits, nil
+}
+
+// commitRefPattern matches keywords followed by a list of issue IDs, e.g.
+// "Fixes mint-a8", "Closes: mint-a8, mint-b2", or "Refs #mint-c4 and mint-d1"
+var commitRefPattern = regexp.MustCompile(`(?i)\b(close[sd]?|fix(?:e[sd])?|resolve[sd]?|refs?|references|see)\b:?[ \t]+(#?[a-z0-9]+(?:-[a-z0-9]+)*(?:[ \t]*(?:,|\band\b)[ \t]*#?[a-z0-9]+(?:-[a-z0-9]+)*)*)`)
+
+var commitIDPattern = regexp.MustCompile(`(?i)#?([a-z0-9]+(?:-[a-z0-9]+)*)`)
+
+// commitRef is an issue referenced from a commit message
+type commitRef struct {
+	ID    string
+	Close bool
+}
+
+// parseCommitRefs finds the issues a commit message closes or references
+// Only aliases and tokens shaped like the store's IDs are considered, so
+// words like "Fixes the build" don't resolve to an issue by prefix
+func parseCommitRefs(message string, store *Store) []commitRef {
+	var refs []commitRef
+	seen := make(map[string]int)
+	for _, match := range commitRefPattern.FindAllStringSubmatch(message, -1) {
+		keyword := strings.ToLower(match[1])
+		isClose := strings.HasPrefix(keyword, "close") || strings.HasPrefix(keyword, "fix") || strings.HasPrefix(keyword, "resolve")
+		for _, token := range commitIDPattern.FindAllStringSubmatch(match[2], -1) {
+			candidate := token[1]
+			if strings.EqualFold(candidate, "and") {
+				continue
+			}
+			var id string
+			if _, isAlias := store.Aliases[candidate]; isAlias || store.Prefix != "" {
+				if !isAlias {
+					// Keywords match in any case, so the prefix does too
+					prefix := store.Prefix + "-"
+					if len(candidate) < len(prefix) || !strings.EqualFold(candidate[:len(prefix)], prefix) {
+						continue
+					}
+					candidate = prefix + candidate[len(prefix):]
+				}
+				resolved, err := store.ResolveIssueID(candidate)
+				if err != nil {
+					continue
+				}
+				id = resolved
+			} else if _, exists := store.Issues[candidate]; exists {
+				id = candidate
+			} else {
+				continue
+			}
+
+			if i, ok := seen[id]; ok {
+				refs[i].Close = refs[i].Close || isClose
+				continue
+			}
+			seen[id] = len(refs)
+			refs = append(refs, commitRef{ID: id, Close: isClose})
+		}
+	}
+	return refs
+}
+
+// commitSyncResult describes what syncing commits changed
+type commitSyncResult struct {
+	Commits   int
+	Closed    []*Issue
+	Commented []*Issue
+}
+
+// SyncCommits closes or comments on the issues referenced by commits
+// An issue whose close reason or comments already mention a commit is
+// skipped for that commit, so syncing the same history twice changes nothing
+func SyncCommits(store *Store, commits []gitCommit) (*commitSyncResult, error) {
+	result := &commitSyncResult{Commits: len(commits)}
+	for _, commit := range commits {
+		mention := "commit " + commit.Short()
+		for _, ref := range parseCommitRefs(commit.Body, store) {
+			issue := store.Issues[ref.ID]
+			if issue == nil || strings.Contains(issue.Reason, mention) ||
+				slices.ContainsFunc(issue.Comments, func(c string) bool { return strings.Contains(c, mention) }) {
+				continue
+			}
+
+			if ref.Close && issue.Status == "open" {
+				// The fix is already committed, so open dependencies can't keep it open
+				if _, err := store.CloseIssues(issue.ID, resolutionDone, fmt.Sprintf("Fixed in %s: %s", mention, commit.Subject), "", false, true); err != nil {
+					return nil, err
+				}
+				result.Closed = append(result.Closed, issue)
+				continue
+			}
+
+			if err := store.AddComment(issue.ID, fmt.Sprintf("Referenced in %s: %s", mention, commit.Subject)); err != nil {
+				return nil, err
+			}
+			result.Commented = append(result.Commented, issue)
+		}
+	}
+	return result, nil
+}
+
+// postCommitHookMarker identifies hooks written by install-hooks
+const postCommitHookMarker = "# Installed by mint install-hooks"
+
+// postCommitHook syncs the commits added by each commit or merge
+const postCommitHook = `#!/bin/sh
+` + postCommitHookMarker + `
+command -v mint >/dev/null 2>&1 || exit 0
+if git rev-parse -q --verify HEAD~1 >/dev/null; then
+	mint sync-commits --since HEAD~1
+else
+	mint sync-commits
+fi
+`
+
+// installPostCommitHook writes the post-commit hook of the repository at dir
+// An existing hook not written by mint is only replaced when force is set
+func installPostCommitHook(dir string, force bool) (string, error) {
+	hooksDir, err := runGit(dir, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(dir, hooksDir)
+	}
+	if err := os.MkdirAll(hooksDir, 0o750); err != nil {
+		return "", err
+	}
+
+	hookPath := filepath.Join(hooksDir, "post-commit")
+	// #nosec G304 -- hookPath is inside the repository's hooks directory
+	if existing, err := os.ReadFile(hookPath); err == nil {
+		if !force && !strings.Contains(string(existing), postCommitHookMarker) {
+			return "", fmt.Errorf("%s already exists (use --force to replace it)", hookPath)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	// #nosec G306 -- git hooks must be executable
+	if err := os.WriteFile(hookPath, []byte(postCommitHook), 0o755); err != nil {
+		return "", err
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(hookPath, 0o755); err != nil { // #nosec G302 -- git hooks must be executable
+		return "", err
+	}
+	return hookPath, nil
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initGitRepo creates a git repository in dir with a fixed identity
+func initGitRepo(t *testing.T, dir string) {
+	t.Helper()
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	if _, err := runGit(dir, "init", "-q"); err != nil {
+		t.Fatalf("git init failed: %v", err)
+	}
+}
+
+// gitCommitMessage makes an empty commit with message in the repository at dir
+func gitCommitMessage(t *testing.T, dir, message string) string {
+	t.Helper()
+	if _, err := runGit(dir, "commit", "-q", "--allow-empty", "-m", message); err != nil {
+		t.Fatalf("git commit failed: %v", err)
+	}
+	hash, err := runGit(dir, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatalf("git rev-parse failed: %v", err)
+	}
+	return hash
+}
+
+func TestParseCommitRefs(t *testing.T) {
+	store := NewStore()
+	store.Issues["mint-abc"] = &Issue{ID: "mint-abc", Status: "open"}
+	store.Issues["mint-def"] = &Issue{ID: "mint-def", Status: "open"}
+	store.Issues["mint-xyz"] = &Issue{ID: "mint-xyz", Status: "open"}
+
+	tests := []struct {
+		message string
+		want    []commitRef
+	}{
+		{"Fixes mint-abc", []commitRef{{"mint-abc", true}}},
+		{"fix parser\n\nCloses: mint-abc, mint-def", []commitRef{{"mint-abc", true}, {"mint-def", true}}},
+		{"Resolved #mint-ab and mint-x", []commitRef{{"mint-abc", true}, {"mint-xyz", true}}},
+		{"Refs mint-abc", []commitRef{{"mint-abc", false}}},
+		{"FIXES MINT-abc", []commitRef{{"mint-abc", true}}},
+		{"See mint-def for context\nFixes mint-def", []commitRef{{"mint-def", true}}},
+		{"Fixes the build", nil},
+		{"Fixes mint-nope", nil},
+		{"Mentions mint-abc without a keyword", nil},
+	}
+
+	for _, tt := range tests {
+		got := parseCommitRefs(tt.message, store)
+		if len(got) != len(tt.want) {
+			t.Errorf("parseCommitRefs(%q) = %v, want %v", tt.message, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("parseCommitRefs(%q) = %v, want %v", tt.message, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestParseCommitRefs_NoPrefixRequiresFullID(t *testing.T) {
+	store := NewStore()
+	store.Prefix = ""
+	store.Issues["the7k"] = &Issue{ID: "the7k", Status: "open"}
+
+	if got := parseCommitRefs("Fixes the build", store); len(got) != 0 {
+		t.Errorf("expected no refs for a partial ID, got %v", got)
+	}
+	if got := parseCommitRefs("Fixes the7k", store); len(got) != 1 {
+		t.Errorf("expected full ID to match, got %v", got)
+	}
+}
+
+func TestParseCommitRefs_Aliases(t *testing.T) {
+	store := NewStore()
+	issue, _ := store.AddIssue("Parser crash")
+	_ = store.SetPrefix("am")
+	if _, err := store.AddAlias("parser", issue.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	oldID := "mint-" + strings.TrimPrefix(issue.ID, "am-")
+
+	for _, message := range []string{"Fixes " + oldID, "Fixes parser"} {
+		got := parseCommitRefs(message, store)
+		if len(got) != 1 || got[0] != (commitRef{issue.ID, true}) {
+			t.Errorf("parseCommitRefs(%q) = %v, want %s", message, got, issue.ID)
+		}
+	}
+	if got := parseCommitRefs("Fixes "+oldID[:len(oldID)-1], store); len(got) != 0 {
+		t.Errorf("expected partial old IDs not to match, got %v", got)
+	}
+}
+
+func TestSyncCommits(t *testing.T) {
+	store := NewStore()
+	fixed, _ := store.AddIssue("Parser crash")
+	referenced, _ := store.AddIssue("Refactor lexer")
+
+	commits := []gitCommit{
+		{Hash: "1111111aaaa", Subject: "Fix parser crash", Body: "Fix parser crash\n\nFixes " + fixed.ID + "\n"},
+		{Hash: "2222222bbbb", Subject: "Split lexer", Body: "Split lexer\n\nRefs " + referenced.ID + "\n"},
+	}
+
+	result, err := SyncCommits(store, commits)
+	if err != nil {
+		t.Fatalf("SyncCommits failed: %v", err)
+	}
+	if len(result.Closed) != 1 || len(result.Commented) != 1 {
+		t.Fatalf("expected 1 closed and 1 commented, got %d and %d", len(result.Closed), len(result.Commented))
+	}
+	if fixed.Status != "closed" {
+		t.Errorf("expected fixed issue to be closed, got %s", fixed.Status)
+	}
+	if want := "Fixed in commit 1111111: Fix parser crash"; fixed.Reason != want {
+		t.Errorf("expected reason %q, got %q", want, fixed.Reason)
+	}
+	if want := "Referenced in commit 2222222: Split lexer"; referenced.Comments[0] != want {
+		t.Errorf("expected comment %q, got %q", want, referenced.Comments[0])
+	}
+
+	// Syncing again doesn't comment on the issue the commit closed
+	result, err = SyncCommits(store, commits)
+	if err != nil {
+		t.Fatalf("SyncCommits failed: %v", err)
+	}
+	if len(result.Closed) != 0 || len(result.Commented) != 0 {
+		t.Errorf("expected second sync to change nothing, got %d closed and %d commented", len(result.Closed), len(result.Commented))
+	}
+	if len(fixed.Comments) != 0 {
+		t.Errorf("expected no comments on the closed issue, got %v", fixed.Comments)
+	}
+
+	// Reopening and syncing again doesn't re-apply the same commits
+	_ = store.ReopenIssue(fixed.ID)
+	result, err = SyncCommits(store, commits)
+	if err != nil {
+		t.Fatalf("SyncCommits failed: %v", err)
+	}
+	if len(result.Closed) != 0 || len(result.Commented) != 0 {
+		t.Errorf("expected second sync to change nothing, got %d closed and %d commented", len(result.Closed), len(result.Commented))
+	}
+	if fixed.Status != "open" {
+		t.Errorf("expected reopened issue to stay open, got %s", fixed.Status)
+	}
+}
+
+func TestReadCommits(t *testing.T) {
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	first := gitCommitMessage(t, dir, "First")
+	gitCommitMessage(t, dir, "Second\n\nFixes mint-abc")
+
+	commits, err := readCommits(dir, "")
+	if err != nil {
+		t.Fatalf("readCommits failed: %v", err)
+	}
+	if len(commits) != 2 || commits[0].Subject != "First" || commits[1].Subject != "Second" {
+		t.Fatalf("expected commits oldest first, got %+v", commits)
+	}
+	if !strings.Contains(commits[1].Body, "Fixes mint-abc") {
+		t.Errorf("expected body to include trailer, got %q", commits[1].Body)
+	}
+
+	commits, err = readCommits(dir, first)
+	if err != nil {
+		t.Fatalf("readCommits failed: %v", err)
+	}
+	if len(commits) != 1 || commits[0].Subject != "Second" {
+		t.Errorf("expected only commits after --since, got %+v", commits)
+	}
+}
+
+func TestInstallPostCommitHook(t *testing.T) {
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+
+	hookPath, err := installPostCommitHook(dir, false)
+	if err != nil {
+		t.Fatalf("installPostCommitHook failed: %v", err)
+	}
+	if hookPath != filepath.Join(dir, ".git", "hooks", "post-commit") {
+		t.Errorf("unexpected hook path %s", hookPath)
+	}
+	info, err := os.Stat(hookPath)
+	if err != nil {
+		t.Fatalf("hook not written: %v", err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("expected hook to be executable, got %v", info.Mode())
+	}
+
+	// Reinstalling over our own hook is fine
+	if _, err := installPostCommitHook(dir, false); err != nil {
+		t.Errorf("reinstalling failed: %v", err)
+	}
+
+	// A hook written by someone else needs --force
+	if err := os.WriteFile(hookPath, []byte("#!/bin/sh\necho custom\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := installPostCommitHook(dir, false); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("expected error mentioning --force, got %v", err)
+	}
+	if _, err := installPostCommitHook(dir, true); err != nil {
+		t.Fatalf("forced install failed: %v", err)
+	}
+	data, _ := os.ReadFile(hookPath)
+	if !strings.Contains(string(data), postCommitHookMarker) {
+		t.Errorf("expected hook to be replaced, got %s", data)
+	}
+	info, _ = os.Stat(hookPath)
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("expected replaced hook to be executable, got %v", info.Mode())
+	}
+}
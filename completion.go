@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// completionShellEnv tells completion which shell is asking, so zsh and
+// fish get titles as descriptions
+const completionShellEnv = "MINT_COMPLETION_SHELL"
+
+// completionScripts are the scripts printed by completion, formatted with
+// the command name. Each asks the command itself for completions.
+var completionScripts = map[string]string{
+	"bash": `# bash completion for %[1]s
+# Add to ~/.bashrc: source <(%[1]s completion bash)
+
+_%[1]s_complete() {
+  local cur="${COMP_WORDS[COMP_CWORD]}"
+  local words=("${COMP_WORDS[@]:1:COMP_CWORD-1}")
+  if [[ "$cur" == -* ]]; then
+    words+=("$cur")
+  fi
+  local IFS=$'\n'
+  COMPREPLY=($(compgen -W "$(` + completionShellEnv + `=bash %[1]s "${words[@]}" --generate-shell-completion 2>/dev/null)" -- "$cur"))
+}
+
+complete -o bashdefault -o default -F _%[1]s_complete %[1]s
+`,
+	"zsh": `#compdef %[1]s
+# zsh completion for %[1]s
+# Add to ~/.zshrc: source <(%[1]s completion zsh)
+
+_%[1]s() {
+  local -a opts args
+  args=("${(@)words[2,CURRENT-1]}")
+  if [[ "${words[CURRENT]}" == -* ]]; then
+    args+=("${words[CURRENT]}")
+  fi
+  opts=("${(@f)$(` + completionShellEnv + `=zsh %[1]s "${args[@]}" --generate-shell-completion 2>/dev/null)}")
+  if [[ -n "${opts[1]}" ]]; then
+    _describe 'values' opts
+  else
+    _files
+  fi
+}
+
+if [ "$funcstack[1]" = "_%[1]s" ]; then
+  _%[1]s "$@"
+else
+  compdef _%[1]s %[1]s
+fi
+`,
+	"fish": `# fish completion for %[1]s
+# Install with: %[1]s completion fish > ~/.config/fish/completions/%[1]s.fish
+
+function __%[1]s_complete
+    set -l args (commandline -opc)[2..-1]
+    set -l cur (commandline -ct)
+    if string match -q -- '-*' "$cur"
+        set args $args $cur
+    end
+    env ` + completionShellEnv + `=fish %[1]s $args --generate-shell-completion 2>/dev/null
+end
+
+complete -c %[1]s -f -a '(__%[1]s_complete)'
+complete -c %[1]s -n '__fish_seen_subcommand_from import scan' -F
+`,
+}
+
+// configureCompletionCommand replaces the built-in completion command,
+// whose fish script can't complete issue IDs
+func configureCompletionCommand(cmd *cli.Command) {
+	cmd.Hidden = false
+	cmd.Usage = "Print a shell completion script for bash, zsh, or fish"
+	cmd.ArgsUsage = "bash|zsh|fish"
+	cmd.Description = "bash: source <(mint completion bash) in ~/.bashrc\n" +
+		"zsh:  source <(mint completion zsh) in ~/.zshrc\n" +
+		"fish: mint completion fish > ~/.config/fish/completions/mint.fish"
+	cmd.Action = completionAction
+}
+
+func completionAction(_ context.Context, cmd *cli.Command) error {
+	shells := slices.Sorted(maps.Keys(completionScripts))
+	if cmd.Args().Len() == 0 {
+		return fmt.Errorf("shell is required (one of %s)", strings.Join(shells, ", "))
+	}
+	script, ok := completionScripts[cmd.Args().First()]
+	if !ok {
+		return fmt.Errorf("unknown shell %q (must be one of %s)", cmd.Args().First(), strings.Join(shells, ", "))
+	}
+	_, err := fmt.Fprintf(cmd.Root().Writer, script, cmd.Root().Name)
+	return err
+}
+
+// issueFilter selects the issues offered when completing an issue ID
+type issueFilter func(*Issue) bool
+
+func anyIssue(*Issue) bool { return true }
+
+func openIssue(issue *Issue) bool { return issue.Status != "closed" }
+
+func closedIssue(issue *Issue) bool { return issue.Status == "closed" }
+
+// completeIssues returns a completion function offering the IDs of issues
+// matching arg for the command's argument, and of issues matching
+// flags[name] for the value of each named flag
+func completeIssues(arg issueFilter, flags map[string]issueFilter) cli.ShellCompleteFunc {
+	return completeIssueArgs(1, arg, flags)
+}
+
+// completeIssueArgs is completeIssues for a command taking n issue IDs
+func completeIssueArgs(n int, arg issueFilter, flags map[string]issueFilter) cli.ShellCompleteFunc {
+	return func(_ context.Context, cmd *cli.Command) {
+		w := cmd.Root().Writer
+		shell := completionShell()
+
+		// The root keeps everything after the command name unparsed; the
+		// last word is either a partial flag or the word before the cursor
+		words := cmd.Root().Args().Slice()
+		last := ""
+		if len(words) > 1 {
+			last = words[len(words)-1]
+		}
+
+		if strings.HasPrefix(last, "-") {
+			flag := findFlag(cmd, last)
+			switch {
+			case flag == nil:
+				printFlagCompletions(w, shell, cmd, last)
+				return
+			case flags[flag.Names()[0]] != nil:
+				printIssueCompletions(w, shell, flags[flag.Names()[0]])
+				return
+			case !isBoolFlag(flag):
+				// The flag takes a value we can't complete
+				return
+			}
+		}
+
+		if arg != nil && cmd.Args().Len() < n {
+			printIssueCompletions(w, shell, arg)
+		}
+	}
+}
+
+// completionShell returns the shell asking for completions
+func completionShell() string {
+	if shell := os.Getenv(completionShellEnv); shell != "" {
+		return shell
+	}
+	// Scripts from the cli library don't set the variable; its zsh script
+	// expects descriptions when $SHELL is zsh
+	if strings.HasSuffix(os.Getenv("SHELL"), "zsh") {
+		return "zsh"
+	}
+	return "bash"
+}
+
+// printIssueCompletions prints the IDs of the issues matching filter, with
+// their titles as descriptions for shells that show them
+func printIssueCompletions(w io.Writer, shell string, filter issueFilter) {
+	filePath, err := GetStoreFilePath()
+	if err != nil {
+		return
+	}
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return
+	}
+	for _, issue := range store.ListIssues() {
+		if filter(issue) {
+			printCompletion(w, shell, issue.ID, issue.Title)
+		}
+	}
+}
+
+// printFlagCompletions prints the command's long flags that start with the
+// partial flag typed so far
+func printFlagCompletions(w io.Writer, shell string, cmd *cli.Command, partial string) {
+	partial = strings.TrimLeft(partial, "-")
+	for _, flag := range cmd.Flags {
+		name := flag.Names()[0]
+		if len(name) < 2 || !strings.HasPrefix(name, partial) {
+			continue
+		}
+		usage := ""
+		if doc, ok := flag.(cli.DocGenerationFlag); ok {
+			usage = doc.GetUsage()
+		}
+		printCompletion(w, shell, "--"+name, usage)
+	}
+}
+
+func printCompletion(w io.Writer, shell, value, description string) {
+	description = strings.Join(strings.Fields(description), " ")
+	switch {
+	case description == "" || shell == "bash":
+		_, _ = fmt.Fprintln(w, value)
+	case shell == "zsh":
+		_, _ = fmt.Fprintf(w, "%s:%s\n", strings.ReplaceAll(value, ":", `\:`), description)
+	default:
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", value, description)
+	}
+}
+
+// findFlag returns the flag arg names, as -x or --name, or nil
+func findFlag(cmd *cli.Command, arg string) cli.Flag {
+	for _, flag := range cmd.Flags {
+		for _, name := range flag.Names() {
+			if len(name) == 1 && arg == "-"+name || len(name) > 1 && arg == "--"+name {
+				return flag
+			}
+		}
+	}
+	return nil
+}
+
+func isBoolFlag(flag cli.Flag) bool {
+	_, ok := flag.(*cli.BoolFlag)
+	return ok
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCompleteIssueIDs(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	t.Setenv(completionShellEnv, "bash")
+
+	store, _ := LoadStore(filePath)
+	open, _ := store.AddIssue("Open issue")
+	closed, _ := store.AddIssue("Closed issue")
+	_ = store.CloseIssue(closed.ID, "")
+	_ = store.Save(filePath)
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"close offers open issues", []string{"close"}, []string{open.ID}},
+		{"open offers closed issues", []string{"open"}, []string{closed.ID}},
+		{"show offers every issue", []string{"show"}, []string{open.ID, closed.ID}},
+		{"only the first argument", []string{"show", open.ID}, nil},
+		{"duplicate flag", []string{"close", open.ID, "--of"}, []string{open.ID, closed.ID}},
+		{"second merge argument", []string{"merge", open.ID}, []string{open.ID, closed.ID}},
+		{"only two merge arguments", []string{"merge", open.ID, closed.ID}, nil},
+		{"dependency flag", []string{"update", closed.ID, "--depends-on"}, []string{open.ID}},
+		{"short dependency flag", []string{"create", "title", "-b"}, []string{open.ID}},
+		{"remove flag", []string{"update", open.ID, "--remove-blocks"}, []string{open.ID, closed.ID}},
+		{"value flag", []string{"update", open.ID, "--title"}, nil},
+		{"partial flag", []string{"update", "--remove-d"}, []string{"--remove-depends-on"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := runMint(t, append(tt.args, "--generate-shell-completion")...)
+			if err != nil {
+				t.Fatalf("completion failed: %v", err)
+			}
+			got := strings.Fields(output)
+			if !slices.Equal(got, slices.Sorted(slices.Values(tt.want))) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCompleteIssueDescriptions(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+
+	store, _ := LoadStore(filePath)
+	issue, _ := store.AddIssue("Fix: the parser")
+	_ = store.Save(filePath)
+
+	t.Setenv(completionShellEnv, "zsh")
+	output, _ := runMint(t, "show", "--generate-shell-completion")
+	if strings.TrimSpace(output) != issue.ID+":Fix: the parser" {
+		t.Errorf("expected zsh description, got %q", output)
+	}
+
+	t.Setenv(completionShellEnv, "fish")
+	output, _ = runMint(t, "show", "--generate-shell-completion")
+	if strings.TrimSpace(output) != issue.ID+"\tFix: the parser" {
+		t.Errorf("expected fish description, got %q", output)
+	}
+}
+
+func TestCompletionCommand(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		output, err := runMint(t, "completion", shell)
+		if err != nil {
+			t.Fatalf("completion %s failed: %v", shell, err)
+		}
+		if !strings.Contains(output, completionShellEnv+"="+shell+" mint") {
+			t.Errorf("expected %s script to call mint, got: %s", shell, output)
+		}
+		if strings.Contains(output, "%!") {
+			t.Errorf("%s script has a formatting error: %s", shell, output)
+		}
+	}
+
+	if _, err := runMint(t, "completion", "tcsh"); err == nil {
+		t.Error("expected error for an unknown shell")
+	}
+}
@@ -0,0 +1,377 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	repoConfigFileName = ".mint.yaml"
+	commandKeyPrefix   = "commands."
+)
+
+// configKey describes a setting that can appear in a config file
+type configKey struct {
+	Name     string
+	Default  string
+	Usage    string
+	Env      string // defaults to MINT_ followed by the upper-cased name
+	Validate func(value string) error
+}
+
+// configKeys lists every setting in the order config list shows them
+// Custom commands are stored under commands.<name> and aren't listed here
+var configKeys = []configKey{
+	{Name: "author", Usage: "Name recorded when starting issues (defaults to git user.name)"},
+	{Name: "store", Usage: "Store file path, relative to the project root", Env: "MINT_STORE_FILE"},
+	{Name: "color", Default: "always", Usage: "Use colors: always, auto (only in a terminal), or never", Validate: oneOf("always", "auto", "never")},
+	{Name: "theme.accent", Default: "5", Usage: "256-color code for headers and labels", Validate: intBetween(0, 255)},
+	{Name: "theme.muted", Default: "8", Usage: "256-color code for secondary text", Validate: intBetween(0, 255)},
+	{Name: "id.length", Default: "0", Usage: "Minimum length of new issue IDs (0 grows with the store)", Validate: intBetween(0, 32)},
+	{Name: "id.alphabet", Default: customAlphabet, Usage: "Characters used in new issue IDs", Validate: validateIDAlphabet},
+	{Name: "list.sort", Default: "default", Usage: "Sort order for list: default, created, updated, id, or title", Validate: oneOf(listSortOrders...)},
+	{Name: "list.limit", Default: "0", Usage: "Maximum issues per list section (0 for no limit)", Validate: intBetween(0, 1<<20)},
+	{Name: "create.required", Usage: "Flags create requires: description, comment, depends-on, blocks", Validate: listOf("description", "comment", "depends-on", "blocks")},
+	{Name: "dups.threshold", Default: "70", Usage: "Percent similarity at which create warns about an issue and dups groups it", Validate: intBetween(1, 100)},
+	{Name: "hooks.timeout", Default: "10", Usage: "Seconds a hook in .mint/hooks may run before it's stopped", Validate: intBetween(1, 3600)},
+}
+
+// Config is the merged configuration from defaults, the user config file,
+// the repository config file, and environment variables, in increasing
+// order of precedence. Command-line flags take precedence over all of them.
+type Config struct {
+	values  map[string]string
+	sources map[string]string
+
+	// RepoPath and UserPath are where set writes; they may not exist
+	RepoPath string
+	UserPath string
+}
+
+// findConfigKey returns the setting named name, or nil if there is none
+func findConfigKey(name string) *configKey {
+	for i := range configKeys {
+		if configKeys[i].Name == name {
+			return &configKeys[i]
+		}
+	}
+	return nil
+}
+
+// envName returns the environment variable that overrides the setting
+func (k configKey) envName() string {
+	if k.Env != "" {
+		return k.Env
+	}
+	return "MINT_" + strings.ToUpper(strings.ReplaceAll(k.Name, ".", "_"))
+}
+
+// validateConfigValue checks a value for a setting or custom command
+func validateConfigValue(name, value string) error {
+	if command, ok := strings.CutPrefix(name, commandKeyPrefix); ok {
+		if command == "" || strings.ContainsAny(command, " \t.") {
+			return fmt.Errorf("invalid command name %q", command)
+		}
+		return nil
+	}
+	key := findConfigKey(name)
+	if key == nil {
+		return fmt.Errorf("unknown config key %q", name)
+	}
+	if key.Validate != nil && value != "" {
+		if err := key.Validate(value); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// userConfigPath returns the path of the user config file
+func userConfigPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "mint", "config.yaml"), nil
+}
+
+// LoadConfig loads the configuration for the project containing the
+// current directory
+func LoadConfig() (*Config, error) {
+	root, err := projectRoot()
+	if err != nil {
+		return nil, err
+	}
+	return loadConfigAt(root)
+}
+
+// loadConfigAt loads the configuration for the project at root
+func loadConfigAt(root string) (*Config, error) {
+	userPath, err := userConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{
+		values:   make(map[string]string),
+		sources:  make(map[string]string),
+		RepoPath: filepath.Join(root, repoConfigFileName),
+		UserPath: userPath,
+	}
+	for _, key := range configKeys {
+		cfg.values[key.Name] = key.Default
+		cfg.sources[key.Name] = "default"
+	}
+
+	for _, layer := range []struct{ path, source string }{
+		{userPath, "user config"},
+		{cfg.RepoPath, "repo config"},
+	} {
+		values, err := readConfigFile(layer.path)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range values {
+			cfg.values[name] = value
+			cfg.sources[name] = layer.source
+		}
+	}
+
+	for _, key := range configKeys {
+		if value, ok := os.LookupEnv(key.envName()); ok && value != "" {
+			if err := validateConfigValue(key.Name, value); err != nil {
+				return nil, fmt.Errorf("%s: %w", key.envName(), err)
+			}
+			cfg.values[key.Name] = value
+			cfg.sources[key.Name] = key.envName()
+		}
+	}
+
+	return cfg, nil
+}
+
+// readConfigFile reads a config file into flattened, validated settings
+// A missing file has no settings
+func readConfigFile(path string) (map[string]string, error) {
+	// #nosec G304 -- path is the repo or user config file
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	values := make(map[string]string)
+	flattenConfig("", doc, values)
+	for name, value := range values {
+		if err := validateConfigValue(name, value); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return values, nil
+}
+
+// flattenConfig turns nested YAML maps into dotted keys, joining lists
+// with commas
+func flattenConfig(prefix string, node map[string]any, values map[string]string) {
+	for name, value := range node {
+		key := prefix + name
+		switch v := value.(type) {
+		case map[string]any:
+			flattenConfig(key+".", v, values)
+		case []any:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			values[key] = strings.Join(items, ",")
+		case nil:
+			values[key] = ""
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+// SetConfigValue writes a setting to the config file at path, removing it
+// when value is empty
+func SetConfigValue(path, name, value string) error {
+	if err := validateConfigValue(name, value); err != nil {
+		return err
+	}
+
+	var doc map[string]any
+	// #nosec G304 -- path is the repo or user config file
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if doc == nil {
+		doc = make(map[string]any)
+	}
+
+	// Walk to the parent map, creating it as needed. Custom commands can
+	// contain dots only in their prefix, so split them once.
+	parts := strings.Split(name, ".")
+	if command, ok := strings.CutPrefix(name, commandKeyPrefix); ok {
+		parts = []string{"commands", command}
+	}
+	node := doc
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := node[part].(map[string]any)
+		if !ok {
+			child = make(map[string]any)
+			node[part] = child
+		}
+		node = child
+	}
+	last := parts[len(parts)-1]
+	if value == "" {
+		delete(node, last)
+	} else if key := findConfigKey(name); key != nil && key.Name == "create.required" {
+		node[last] = splitConfigList(value)
+	} else if n, err := strconv.Atoi(value); err == nil && strconv.Itoa(n) == value {
+		node[last] = n
+	} else {
+		node[last] = value
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, out, 0o600)
+}
+
+// Get returns the effective value of a setting or custom command
+func (c *Config) Get(name string) (string, bool) {
+	value, ok := c.values[name]
+	return value, ok
+}
+
+// Source describes where the effective value of a setting came from
+func (c *Config) Source(name string) string {
+	return c.sources[name]
+}
+
+// String returns the value of a setting
+func (c *Config) String(name string) string {
+	return c.values[name]
+}
+
+// Int returns the value of an integer setting, which was validated on load
+func (c *Config) Int(name string) int {
+	n, _ := strconv.Atoi(c.values[name])
+	return n
+}
+
+// List returns the values of a comma-separated setting
+func (c *Config) List(name string) []string {
+	return splitConfigList(c.values[name])
+}
+
+// Commands returns the custom commands, keyed by name
+func (c *Config) Commands() map[string]string {
+	commands := make(map[string]string)
+	for name, value := range c.values {
+		if command, ok := strings.CutPrefix(name, commandKeyPrefix); ok && value != "" {
+			commands[command] = value
+		}
+	}
+	return commands
+}
+
+// Names returns every setting followed by every custom command, sorted
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.values))
+	for _, key := range configKeys {
+		names = append(names, key.Name)
+	}
+	var commands []string
+	for name := range c.Commands() {
+		commands = append(commands, commandKeyPrefix+name)
+	}
+	slices.Sort(commands)
+	return append(names, commands...)
+}
+
+func splitConfigList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func oneOf(choices ...string) func(string) error {
+	return func(value string) error {
+		if !slices.Contains(choices, value) {
+			return fmt.Errorf("must be one of %s", strings.Join(choices, ", "))
+		}
+		return nil
+	}
+}
+
+func listOf(choices ...string) func(string) error {
+	return func(value string) error {
+		for _, item := range splitConfigList(value) {
+			if !slices.Contains(choices, item) {
+				return fmt.Errorf("unknown value %q (must be one of %s)", item, strings.Join(choices, ", "))
+			}
+		}
+		return nil
+	}
+}
+
+func intBetween(lo, hi int) func(string) error {
+	return func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < lo || n > hi {
+			return fmt.Errorf("must be a number from %d to %d", lo, hi)
+		}
+		return nil
+	}
+}
+
+// validateIDAlphabet allows only distinct lowercase letters and digits, so
+// IDs stay easy to type and to find in commit messages
+func validateIDAlphabet(value string) error {
+	seen := make(map[rune]bool)
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return fmt.Errorf("may only contain lowercase letters and digits")
+		}
+		if seen[r] {
+			return fmt.Errorf("contains %q more than once", r)
+		}
+		seen[r] = true
+	}
+	if len(seen) < 2 {
+		return fmt.Errorf("must contain at least 2 characters")
+	}
+	return nil
+}
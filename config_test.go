@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// Keep the developer's own config out of tests
+	configHome, err := os.MkdirTemp("", "mint-config-")
+	if err != nil {
+		panic(err)
+	}
+	_ = os.Setenv("XDG_CONFIG_HOME", configHome)
+	_ = os.Unsetenv("NO_COLOR")
+	for _, key := range configKeys {
+		if key.Env == "" {
+			_ = os.Unsetenv(key.envName())
+		}
+	}
+	code := m.Run()
+	_ = os.RemoveAll(configHome)
+	os.Exit(code)
+}
+
+// writeConfigFile writes a config file, creating its directory
+func writeConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cfg, err := loadConfigAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("loadConfigAt failed: %v", err)
+	}
+	if cfg.String("color") != "always" || cfg.Source("color") != "default" {
+		t.Errorf("expected default color, got %q from %s", cfg.String("color"), cfg.Source("color"))
+	}
+	if cfg.Int("list.limit") != 0 {
+		t.Errorf("expected no default limit, got %d", cfg.Int("list.limit"))
+	}
+	if len(cfg.Commands()) != 0 {
+		t.Errorf("expected no commands, got %v", cfg.Commands())
+	}
+}
+
+func TestLoadConfig_Precedence(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	root := t.TempDir()
+
+	writeConfigFile(t, filepath.Join(configHome, "mint", "config.yaml"),
+		"author: User Name\nlist:\n  limit: 5\n  sort: title\ncommands:\n  r: list --ready\n")
+	writeConfigFile(t, filepath.Join(root, ".mint.yaml"),
+		"list:\n  limit: 10\ncreate:\n  required: [description, comment]\n")
+	t.Setenv("MINT_LIST_SORT", "updated")
+
+	cfg, err := loadConfigAt(root)
+	if err != nil {
+		t.Fatalf("loadConfigAt failed: %v", err)
+	}
+
+	tests := []struct {
+		key    string
+		value  string
+		source string
+	}{
+		{"author", "User Name", "user config"},
+		{"list.limit", "10", "repo config"},
+		{"list.sort", "updated", "MINT_LIST_SORT"},
+		{"create.required", "description,comment", "repo config"},
+		{"commands.r", "list --ready", "user config"},
+		{"color", "always", "default"},
+	}
+	for _, tt := range tests {
+		if got := cfg.String(tt.key); got != tt.value {
+			t.Errorf("%s = %q, want %q", tt.key, got, tt.value)
+		}
+		if got := cfg.Source(tt.key); got != tt.source {
+			t.Errorf("%s source = %q, want %q", tt.key, got, tt.source)
+		}
+	}
+	if got := cfg.List("create.required"); len(got) != 2 || got[1] != "comment" {
+		t.Errorf("expected required list, got %v", got)
+	}
+	if got := cfg.Commands()["r"]; got != "list --ready" {
+		t.Errorf("expected alias r, got %q", got)
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"colour: never\n", `unknown config key "colour"`},
+		{"color: sometimes\n", "color: must be one of always, auto, never"},
+		{"list:\n  limit: lots\n", "list.limit: must be a number"},
+		{"id:\n  alphabet: ABC\n", "id.alphabet: may only contain lowercase letters and digits"},
+		{"create:\n  required: [labels]\n", `unknown value "labels"`},
+		{"color: [\n", ".mint.yaml"},
+	}
+	for _, tt := range tests {
+		root := t.TempDir()
+		writeConfigFile(t, filepath.Join(root, ".mint.yaml"), tt.content)
+		_, err := loadConfigAt(root)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("config %q: expected error containing %q, got %v", tt.content, tt.want, err)
+		}
+	}
+
+	t.Setenv("MINT_COLOR", "purple")
+	if _, err := loadConfigAt(t.TempDir()); err == nil || !strings.Contains(err.Error(), "MINT_COLOR") {
+		t.Errorf("expected env var error, got %v", err)
+	}
+}
+
+func TestSetConfigValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", ".mint.yaml")
+
+	for _, kv := range [][2]string{
+		{"list.limit", "10"},
+		{"list.sort", "id"},
+		{"create.required", "description, comment"},
+		{"commands.r", "list --ready"},
+		{"author", "Alice"},
+	} {
+		if err := SetConfigValue(path, kv[0], kv[1]); err != nil {
+			t.Fatalf("SetConfigValue(%s) failed: %v", kv[0], err)
+		}
+	}
+	if err := SetConfigValue(path, "author", ""); err != nil {
+		t.Fatalf("removing author failed: %v", err)
+	}
+
+	values, err := readConfigFile(path)
+	if err != nil {
+		t.Fatalf("readConfigFile failed: %v", err)
+	}
+	want := map[string]string{
+		"list.limit":      "10",
+		"list.sort":       "id",
+		"create.required": "description,comment",
+		"commands.r":      "list --ready",
+	}
+	if len(values) != len(want) {
+		t.Errorf("expected %d values, got %v", len(want), values)
+	}
+	for key, value := range want {
+		if values[key] != value {
+			t.Errorf("%s = %q, want %q", key, values[key], value)
+		}
+	}
+
+	if err := SetConfigValue(path, "list.limit", "-1"); err == nil {
+		t.Error("expected invalid value to be rejected")
+	}
+	if err := SetConfigValue(path, "nope", "1"); err == nil {
+		t.Error("expected unknown key to be rejected")
+	}
+}
+
+func TestExpandCommand(t *testing.T) {
+	cmd := newCommand()
+	commands := map[string]string{
+		"r":    "list --ready",
+		"bug":  `create --comment "Reported by QA"`,
+		"list": "list --open",
+	}
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"mint", "r", "--limit", "3"}, []string{"mint", "list", "--ready", "--limit", "3"}},
+		{[]string{"mint", "bug", "Crash"}, []string{"mint", "create", "--comment", "Reported by QA", "Crash"}},
+		{[]string{"mint", "list"}, []string{"mint", "list"}},
+		{[]string{"mint", "show", "r"}, []string{"mint", "show", "r"}},
+		{[]string{"mint"}, []string{"mint"}},
+	}
+	for _, tt := range tests {
+		got, err := expandCommand(cmd, tt.args, commands)
+		if err != nil {
+			t.Fatalf("expandCommand(%v) failed: %v", tt.args, err)
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("expandCommand(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestApplyColorConfig(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	root := t.TempDir()
+	output := "\x1b[1m\x1b[38;5;5mID\x1b[0m \x1b[38;5;8mmuted\x1b[0m\n"
+
+	cfg, _ := loadConfigAt(root)
+	var buf bytes.Buffer
+	if w := applyColorConfig(&buf, cfg); w != &buf {
+		t.Error("expected default config to leave the writer alone")
+	}
+
+	writeConfigFile(t, filepath.Join(root, ".mint.yaml"), "theme:\n  accent: 33\n")
+	cfg, _ = loadConfigAt(root)
+	buf.Reset()
+	_, _ = applyColorConfig(&buf, cfg).Write([]byte(output))
+	if !strings.Contains(buf.String(), "\x1b[38;5;33mID") || !strings.Contains(buf.String(), "\x1b[38;5;8mmuted") {
+		t.Errorf("expected accent color to be replaced, got %q", buf.String())
+	}
+
+	writeConfigFile(t, filepath.Join(root, ".mint.yaml"), "color: auto\n")
+	cfg, _ = loadConfigAt(root)
+	buf.Reset()
+	_, _ = applyColorConfig(&buf, cfg).Write([]byte(output))
+	if buf.String() != "ID muted\n" {
+		t.Errorf("expected auto to strip colors when not a terminal, got %q", buf.String())
+	}
+
+	writeConfigFile(t, filepath.Join(root, ".mint.yaml"), "")
+	t.Setenv("NO_COLOR", "1")
+	cfg, _ = loadConfigAt(root)
+	buf.Reset()
+	_, _ = applyColorConfig(&buf, cfg).Write([]byte(output))
+	if buf.String() != "ID muted\n" {
+		t.Errorf("expected NO_COLOR to strip colors, got %q", buf.String())
+	}
+}
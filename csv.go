@@ -0,0 +1,392 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvColumn describes a column that can be exported to CSV
+type csvColumn struct {
+	Name   string
+	Export func(issue *Issue) string
+}
+
+// csvListSeparator separates values in list columns like depends_on
+const csvListSeparator = ";"
+
+// csvColumns lists every exportable column in default order
+// New issue fields should be added here to make them available in CSV
+var csvColumns = []csvColumn{
+	{"id", func(issue *Issue) string { return issue.ID }},
+	{"title", func(issue *Issue) string { return issue.Title }},
+	{"status", func(issue *Issue) string { return issue.Status }},
+	{"created_at", func(issue *Issue) string { return formatCSVTime(issue.CreatedAt) }},
+	{"updated_at", func(issue *Issue) string { return formatCSVTime(issue.UpdatedAt) }},
+	{"depends_on", func(issue *Issue) string { return strings.Join(issue.DependsOn, csvListSeparator) }},
+	{"blocks", func(issue *Issue) string { return strings.Join(issue.Blocks, csvListSeparator) }},
+	{"comment_count", func(issue *Issue) string { return strconv.Itoa(len(issue.Comments)) }},
+	{"labels", func(issue *Issue) string { return strings.Join(issue.Labels, csvListSeparator) }},
+	{"external_ref", func(issue *Issue) string { return issue.ExternalRef }},
+	{"owner", func(issue *Issue) string { return issue.Owner }},
+	{"branch", func(issue *Issue) string { return issue.Branch }},
+	{"resolution", func(issue *Issue) string { return issue.Resolution }},
+	{"duplicate_of", func(issue *Issue) string { return issue.DuplicateOf }},
+	{"reason", func(issue *Issue) string { return issue.Reason }},
+	{"closed_at", func(issue *Issue) string { return formatCSVTime(issue.ClosedAt) }},
+}
+
+// csvReadOnlyColumns are exported but ignored on import
+var csvReadOnlyColumns = []string{"created_at", "updated_at", "comment_count", "closed_at"}
+
+func formatCSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// csvColumnNames returns the names of every exportable column
+func csvColumnNames() []string {
+	names := make([]string, len(csvColumns))
+	for i, column := range csvColumns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// ExportCSV writes every issue in the store as a CSV row with the given
+// columns, or every column if none are given
+func ExportCSV(store *Store, w io.Writer, columnNames []string) error {
+	if len(columnNames) == 0 {
+		columnNames = csvColumnNames()
+	}
+
+	columns := make([]csvColumn, 0, len(columnNames))
+	for _, name := range columnNames {
+		i := slices.IndexFunc(csvColumns, func(c csvColumn) bool { return c.Name == name })
+		if i < 0 {
+			return fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(csvColumnNames(), ", "))
+		}
+		columns = append(columns, csvColumns[i])
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columnNames); err != nil {
+		return err
+	}
+	for _, issue := range store.ListIssues() {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = column.Export(issue)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvClose is a close or change of resolution from a CSV row
+type csvClose struct {
+	row         int
+	issue       *Issue
+	resolution  string
+	reason      string
+	duplicateOf string
+}
+
+// splitCSVList splits a list column, accepting semicolons, commas, or
+// whitespace as separators
+func splitCSVList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
+
+// ImportCSV creates or updates issues from CSV rows, matching rows to issues
+// by the id column. Rows whose id is empty or unknown create new issues, and
+// their id can be used in relationship columns of other rows.
+// Every change is described in result.Changes, so callers can preview an
+// import by not saving the store. Closes are refused while the issue
+// depends on open issues, unless force is set.
+func ImportCSV(store *Store, r io.Reader, force bool) (*importResult, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+
+	header := make(map[string]int)
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(csvColumnNames(), name) {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(csvColumnNames(), ", "))
+		}
+		if slices.Contains(csvReadOnlyColumns, name) {
+			continue
+		}
+		header[name] = i
+	}
+	if _, ok := header["id"]; !ok {
+		if _, ok := header["title"]; !ok {
+			return nil, fmt.Errorf("CSV must have an id or title column")
+		}
+	}
+
+	rows := records[1:]
+	field := func(row []string, name string) (string, bool) {
+		i, ok := header[name]
+		if !ok || i >= len(row) {
+			return "", ok
+		}
+		return strings.TrimSpace(row[i]), true
+	}
+
+	result := &importResult{}
+	issues := make([]*Issue, len(rows))
+	localIDs := make(map[string]string)
+	changed := make(map[*Issue]bool)
+	created := make(map[*Issue]bool)
+	change := func(issue *Issue, format string, args ...any) {
+		result.Changes = append(result.Changes, issue.ID+": "+fmt.Sprintf(format, args...))
+		changed[issue] = true
+	}
+
+	for i, row := range rows {
+		rowNum := i + 2
+		id, _ := field(row, "id")
+		title, _ := field(row, "title")
+
+		var issue *Issue
+		if id != "" {
+			if fullID, err := store.ResolveIssueID(id); err == nil {
+				issue = store.Issues[fullID]
+			}
+		}
+		if issue == nil {
+			if title == "" {
+				return nil, fmt.Errorf("row %d: title is required to create an issue", rowNum)
+			}
+			var err error
+			issue, err = store.AddIssue(title)
+			if err != nil {
+				return nil, err
+			}
+			created[issue] = true
+			result.Changes = append(result.Changes, fmt.Sprintf("%s: create %q", issue.ID, title))
+			if id != "" {
+				localIDs[id] = issue.ID
+			}
+		}
+		issues[i] = issue
+
+		if title != "" && issue.Title != title {
+			change(issue, "title %q -> %q", issue.Title, title)
+			if err := store.UpdateIssueTitle(issue.ID, title); err != nil {
+				return nil, err
+			}
+		}
+
+		if status, _ := field(row, "status"); status != "" && status != "open" && status != "closed" {
+			return nil, fmt.Errorf("row %d: invalid status %q (must be open or closed)", rowNum, status)
+		}
+
+		if value, ok := field(row, "labels"); ok {
+			labels := splitCSVList(value)
+			if !slices.Equal(issue.Labels, labels) && (len(issue.Labels) > 0 || len(labels) > 0) {
+				change(issue, "labels [%s] -> [%s]", strings.Join(issue.Labels, ", "), strings.Join(labels, ", "))
+				issue.Labels = labels
+				store.touch(issue)
+			}
+		}
+
+		if ref, ok := field(row, "external_ref"); ok && ref != issue.ExternalRef {
+			change(issue, "external ref %q -> %q", issue.ExternalRef, ref)
+			issue.ExternalRef = ref
+			store.touch(issue)
+		}
+
+		if owner, ok := field(row, "owner"); ok && owner != issue.Owner {
+			change(issue, "owner %q -> %q", issue.Owner, owner)
+			issue.Owner = owner
+			store.touch(issue)
+		}
+
+		if branch, ok := field(row, "branch"); ok && branch != issue.Branch {
+			change(issue, "branch %q -> %q", issue.Branch, branch)
+			issue.Branch = branch
+			store.touch(issue)
+		}
+	}
+
+	resolve := func(ref string) (string, error) {
+		if id, ok := localIDs[ref]; ok {
+			return id, nil
+		}
+		return store.ResolveIssueID(ref)
+	}
+	// Apply relationships once every row's issue exists. Each cell is diffed
+	// against the relationships from before the import, so editing only the
+	// depends_on side of an edge isn't undone by the untouched blocks side.
+	original := make(map[string][2][]string, len(store.Issues))
+	for id, issue := range store.Issues {
+		original[id] = [2][]string{slices.Clone(issue.DependsOn), slices.Clone(issue.Blocks)}
+	}
+	relationships := []struct {
+		column  string
+		current func(issue *Issue) []string
+		add     func(issueID, otherID string) error
+		remove  func(issueID, otherID string) error
+		verb    string
+	}{
+		{"depends_on", func(issue *Issue) []string { return issue.DependsOn }, store.AddDependency, store.RemoveDependency, "dependency on"},
+		{"blocks", func(issue *Issue) []string { return issue.Blocks }, store.AddBlocker, store.RemoveBlocker, "blocks"},
+	}
+	for r, rel := range relationships {
+		for i, row := range rows {
+			value, ok := field(row, rel.column)
+			if !ok {
+				continue
+			}
+			issue := issues[i]
+
+			var wanted []string
+			for _, ref := range splitCSVList(value) {
+				otherID, err := resolve(ref)
+				if err != nil {
+					return nil, fmt.Errorf("row %d: %s: %w", i+2, rel.column, err)
+				}
+				if otherID == issue.ID {
+					return nil, fmt.Errorf("row %d: %s: issue cannot reference itself", i+2, rel.column)
+				}
+				wanted = append(wanted, otherID)
+			}
+			before := original[issue.ID][r]
+
+			for _, otherID := range wanted {
+				if !slices.Contains(before, otherID) && !slices.Contains(rel.current(issue), otherID) {
+					change(issue, "add %s %s", rel.verb, otherID)
+					if err := rel.add(issue.ID, otherID); err != nil {
+						return nil, err
+					}
+				}
+			}
+			for _, otherID := range before {
+				if !slices.Contains(wanted, otherID) && slices.Contains(rel.current(issue), otherID) && store.Issues[otherID] != nil {
+					change(issue, "remove %s %s", rel.verb, otherID)
+					if err := rel.remove(issue.ID, otherID); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+
+	// Close and reopen once the relationships are in place, so duplicate_of
+	// can name an issue created by a later row and closes are checked
+	// against the imported dependencies. Closed issues keep the reason and
+	// duplicate_of they have for columns the CSV leaves out, and new closes
+	// are done unless they name what they duplicate.
+	var closes []csvClose
+	for i, row := range rows {
+		issue := issues[i]
+		status, _ := field(row, "status")
+		resolution, _ := field(row, "resolution")
+		reason, hasReason := field(row, "reason")
+		duplicateOf, hasDuplicateOf := field(row, "duplicate_of")
+
+		if status == "open" || (status == "" && issue.Status == "open") {
+			if resolution != "" || reason != "" || duplicateOf != "" {
+				return nil, fmt.Errorf("row %d: resolution, reason, and duplicate_of are only allowed for closed issues", i+2)
+			}
+			if issue.Status == "closed" {
+				if err := store.ReopenIssue(issue.ID); err != nil {
+					return nil, err
+				}
+				change(issue, "status -> open")
+			}
+			continue
+		}
+
+		wasClosed := issue.Status == "closed"
+		if duplicateOf != "" {
+			id, err := resolve(duplicateOf)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: duplicate_of: %w", i+2, err)
+			}
+			duplicateOf = id
+		}
+		if wasClosed && !hasReason {
+			reason = issue.Reason
+		}
+		if wasClosed && !hasDuplicateOf {
+			duplicateOf = issue.DuplicateOf
+		}
+		if resolution == "" {
+			switch {
+			case wasClosed:
+				resolution = issue.Resolution
+			case duplicateOf != "":
+				resolution = resolutionDuplicate
+			default:
+				resolution = resolutionDone
+			}
+		}
+		if wasClosed && resolution == issue.Resolution && reason == issue.Reason && duplicateOf == issue.DuplicateOf {
+			continue
+		}
+
+		closes = append(closes, csvClose{i + 2, issue, resolution, reason, duplicateOf})
+	}
+
+	// Issues are closed after the issues they depend on, so closing both in
+	// one file passes the open dependency guard
+	for len(closes) > 0 {
+		next := slices.IndexFunc(closes, func(c csvClose) bool {
+			return !slices.ContainsFunc(closes, func(other csvClose) bool { return slices.Contains(c.issue.DependsOn, other.issue.ID) })
+		})
+		c := closes[next]
+		closes = slices.Delete(closes, next, next+1)
+
+		var err error
+		wasClosed, closedAt := c.issue.Status == "closed", c.issue.ClosedAt
+		if wasClosed {
+			err = store.CloseIssueAs(c.issue.ID, c.resolution, c.reason, c.duplicateOf)
+		} else {
+			_, err = store.CloseIssues(c.issue.ID, c.resolution, c.reason, c.duplicateOf, false, force)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", c.row, err)
+		}
+		switch {
+		case wasClosed:
+			c.issue.ClosedAt = closedAt
+			change(c.issue, "closed as %s", resolutionText(c.issue))
+		case !created[c.issue]:
+			change(c.issue, "status -> closed as %s", resolutionText(c.issue))
+		}
+	}
+
+	for _, issue := range issues {
+		switch {
+		case created[issue]:
+			result.Created = append(result.Created, issue)
+		case changed[issue]:
+			result.Updated = append(result.Updated, issue)
+		default:
+			result.Unchanged++
+		}
+	}
+
+	return result, nil
+}
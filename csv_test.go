@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestExportCSV(t *testing.T) {
+	store := NewStore()
+	a, _ := store.AddIssue("Blocker, with comma")
+	b, _ := store.AddIssue("Blocked")
+	_ = store.AddDependency(b.ID, a.ID)
+	_ = store.AddComment(a.ID, "One")
+	_ = store.AddComment(a.ID, "Two")
+	a.Labels = []string{"infra", "ci"}
+
+	var buf bytes.Buffer
+	if err := ExportCSV(store, &buf, nil); err != nil {
+		t.Fatalf("ExportCSV() failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d records", len(records))
+	}
+	if strings.Join(records[0], ",") != strings.Join(csvColumnNames(), ",") {
+		t.Errorf("expected default header %v, got %v", csvColumnNames(), records[0])
+	}
+
+	rows := make(map[string][]string)
+	for _, record := range records[1:] {
+		rows[record[0]] = record
+	}
+	blocker := rows[a.ID]
+	if blocker[1] != "Blocker, with comma" || blocker[6] != b.ID || blocker[7] != "2" || blocker[8] != "infra;ci" {
+		t.Errorf("unexpected blocker row: %v", blocker)
+	}
+	if rows[b.ID][5] != a.ID {
+		t.Errorf("expected depends_on %s, got %v", a.ID, rows[b.ID])
+	}
+}
+
+func TestExportCSV_Columns(t *testing.T) {
+	store := NewStore()
+	issue, _ := store.AddIssue("Only issue")
+
+	var buf bytes.Buffer
+	if err := ExportCSV(store, &buf, []string{"title", "id"}); err != nil {
+		t.Fatalf("ExportCSV() failed: %v", err)
+	}
+	expected := "title,id\nOnly issue," + issue.ID + "\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	if err := ExportCSV(store, &buf, []string{"priority"}); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
+
+func TestImportCSV_CreateAndUpdate(t *testing.T) {
+	store := NewStore()
+	existing, _ := store.AddIssue("Old title")
+
+	input := "id,title,status,depends_on,labels\n" +
+		existing.ID + ",New title,closed,,\n" +
+		"new-1,Write docs,open,new-2;" + existing.ID + ",docs\n" +
+		"new-2,Pick a theme,,,\n"
+
+	result, err := ImportCSV(store, strings.NewReader(input), false)
+	if err != nil {
+		t.Fatalf("ImportCSV() failed: %v", err)
+	}
+	if len(result.Created) != 2 || len(result.Updated) != 1 {
+		t.Fatalf("expected 2 created and 1 updated, got %d and %d", len(result.Created), len(result.Updated))
+	}
+
+	if existing.Title != "New title" || existing.Status != "closed" {
+		t.Errorf("expected existing issue to be updated, got %q %s", existing.Title, existing.Status)
+	}
+
+	docs, theme := result.Created[0], result.Created[1]
+	if docs.Title != "Write docs" || theme.Title != "Pick a theme" {
+		t.Fatalf("unexpected created issues: %q, %q", docs.Title, theme.Title)
+	}
+	if len(docs.DependsOn) != 2 || docs.DependsOn[0] != theme.ID || docs.DependsOn[1] != existing.ID {
+		t.Errorf("expected docs to depend on [%s %s], got %v", theme.ID, existing.ID, docs.DependsOn)
+	}
+	if len(theme.Blocks) != 1 || theme.Blocks[0] != docs.ID {
+		t.Errorf("expected relationship symmetry, got %v", theme.Blocks)
+	}
+	if len(docs.Labels) != 1 || docs.Labels[0] != "docs" {
+		t.Errorf("expected labels [docs], got %v", docs.Labels)
+	}
+}
+
+func TestImportCSV_RoundTripIsNoOp(t *testing.T) {
+	store := NewStore()
+	a, _ := store.AddIssue("A")
+	b, _ := store.AddIssue("B")
+	c, _ := store.AddIssue("C")
+	_ = store.AddDependency(b.ID, a.ID)
+	_ = store.CloseIssueAs(c.ID, resolutionDuplicate, "Same crash", a.ID)
+
+	var buf bytes.Buffer
+	if err := ExportCSV(store, &buf, nil); err != nil {
+		t.Fatalf("ExportCSV() failed: %v", err)
+	}
+
+	result, err := ImportCSV(store, &buf, false)
+	if err != nil {
+		t.Fatalf("ImportCSV() failed: %v", err)
+	}
+	if len(result.Changes) != 0 || result.Unchanged != 3 {
+		t.Errorf("expected no changes, got %v", result.Changes)
+	}
+}
+
+func TestImportCSV_Resolutions(t *testing.T) {
+	store := NewStore()
+	a, _ := store.AddIssue("A")
+	b, _ := store.AddIssue("B")
+	c, _ := store.AddIssue("C")
+	_ = store.CloseIssueAs(c.ID, resolutionWontfix, "Too slow", "")
+	closedAt := c.ClosedAt
+
+	input := "id,title,status,resolution,duplicate_of,reason\n" +
+		a.ID + ",,closed,obsolete,,Replaced by the new parser\n" +
+		b.ID + ",,closed,,new-1,\n" +
+		c.ID + ",,,,,Too slow on large files\n" +
+		"new-1,Crash on load,,,,\n"
+
+	result, err := ImportCSV(store, strings.NewReader(input), false)
+	if err != nil {
+		t.Fatalf("ImportCSV() failed: %v", err)
+	}
+	if a.Status != "closed" || a.Resolution != resolutionObsolete || a.Reason != "Replaced by the new parser" {
+		t.Errorf("expected a to be closed as obsolete with the reason, got %+v", a)
+	}
+	created := result.Created[0]
+	if b.Resolution != resolutionDuplicate || b.DuplicateOf != created.ID {
+		t.Errorf("expected b to be a duplicate of %s, got %q of %q", created.ID, b.Resolution, b.DuplicateOf)
+	}
+	if c.Resolution != resolutionWontfix || c.Reason != "Too slow on large files" || !c.ClosedAt.Equal(closedAt) {
+		t.Errorf("expected only c's reason to change, got %+v", c)
+	}
+
+	expected := []string{
+		"create \"Crash on load\"",
+		"status -> closed as obsolete",
+		"status -> closed as duplicate of " + created.ID,
+		"closed as wontfix",
+	}
+	for i, want := range expected {
+		if i >= len(result.Changes) || !strings.HasSuffix(result.Changes[i], want) {
+			t.Errorf("expected changes ending with %v, got %v", expected, result.Changes)
+			break
+		}
+	}
+}
+
+func TestImportCSV_CloseDependencies(t *testing.T) {
+	store := NewStore()
+	design, _ := store.AddIssue("Design")
+	build, _ := store.AddIssue("Build")
+	_ = store.AddDependency(build.ID, design.ID)
+
+	input := "id,status\n" + build.ID + ",closed\n"
+	if _, err := ImportCSV(store, strings.NewReader(input), false); err == nil || !strings.Contains(err.Error(), "depends on open issues") {
+		t.Errorf("expected closing with an open dependency to be refused, got %v", err)
+	}
+
+	// Closing the dependency in the same file is fine, whatever the row order
+	input = "id,status\n" + build.ID + ",closed\n" + design.ID + ",closed\n"
+	if _, err := ImportCSV(store, strings.NewReader(input), false); err != nil {
+		t.Fatalf("ImportCSV() failed: %v", err)
+	}
+	if design.Status != "closed" || build.Status != "closed" {
+		t.Errorf("expected both issues to be closed, got %s and %s", design.Status, build.Status)
+	}
+
+	_ = store.ReopenIssue(design.ID)
+	_ = store.ReopenIssue(build.ID)
+	input = "id,status\n" + build.ID + ",closed\n"
+	if _, err := ImportCSV(store, strings.NewReader(input), true); err != nil || build.Status != "closed" {
+		t.Errorf("expected force to close build, got %s (%v)", build.Status, err)
+	}
+}
+
+func TestImportCSV_EditOneSideOfRelationship(t *testing.T) {
+	store := NewStore()
+	a, _ := store.AddIssue("A")
+	b, _ := store.AddIssue("B")
+	c, _ := store.AddIssue("C")
+	_ = store.AddDependency(b.ID, a.ID)
+
+	// Move b's dependency from a to c, leaving the stale blocks cells alone
+	input := "id,depends_on,blocks\n" +
+		a.ID + ",," + b.ID + "\n" +
+		b.ID + "," + c.ID + ",\n" +
+		c.ID + ",,\n"
+
+	result, err := ImportCSV(store, strings.NewReader(input), false)
+	if err != nil {
+		t.Fatalf("ImportCSV() failed: %v", err)
+	}
+
+	if len(b.DependsOn) != 1 || b.DependsOn[0] != c.ID {
+		t.Errorf("expected b to depend on c only, got %v", b.DependsOn)
+	}
+	if len(a.Blocks) != 0 {
+		t.Errorf("expected a to block nothing, got %v", a.Blocks)
+	}
+	if len(c.Blocks) != 1 || c.Blocks[0] != b.ID {
+		t.Errorf("expected c to block b, got %v", c.Blocks)
+	}
+
+	expected := []string{
+		b.ID + ": add dependency on " + c.ID,
+		b.ID + ": remove dependency on " + a.ID,
+	}
+	if strings.Join(result.Changes, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected changes %v, got %v", expected, result.Changes)
+	}
+}
+
+func TestImportCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"unknown column", "id,priority\n"},
+		{"no id or title", "status\nopen\n"},
+		{"missing title", "id\nnew-1\n"},
+		{"invalid status", "title,status\nA,done\n"},
+		{"invalid resolution", "title,status,resolution\nA,closed,fixed\n"},
+		{"resolution when open", "title,status,resolution\nA,open,wontfix\n"},
+		{"duplicate without original", "title,status,resolution\nA,closed,duplicate\n"},
+		{"unknown reference", "title,depends_on\nA,mint-nope\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore()
+			if _, err := ImportCSV(store, strings.NewReader(tt.input), false); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
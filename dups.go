@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// maxSimilar is how many similar issues create warns about
+const maxSimilar = 5
+
+// similarIssue is an open issue that may duplicate another, with how
+// similar they are from 0 to 1
+type similarIssue struct {
+	Issue *Issue
+	Score float64
+}
+
+// issueText is the text of an issue compared to find duplicates
+type issueText struct {
+	title       map[string]bool
+	description map[string]bool
+}
+
+func newIssueText(title, description string) issueText {
+	return issueText{title: trigrams(title), description: trigrams(description)}
+}
+
+// issueDescription returns the first comment, which create --description
+// adds
+func issueDescription(issue *Issue) string {
+	if len(issue.Comments) == 0 {
+		return ""
+	}
+	return issue.Comments[0]
+}
+
+// trigrams returns the character trigrams of the words in text, ignoring
+// case and punctuation. Words are padded so short ones still count.
+func trigrams(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	grams := make(map[string]bool)
+	for _, word := range words {
+		padded := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			grams[string(padded[i:i+3])] = true
+		}
+	}
+	return grams
+}
+
+// dice returns the Dice coefficient of two sets: 1 when they're equal, 0
+// when they share nothing
+func dice(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for gram := range a {
+		if b[gram] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(a)+len(b))
+}
+
+// similarity scores how alike two issues are from their titles, and from
+// their descriptions when both have one. Matching descriptions can raise a
+// score but not lower it, since many issues have none.
+func (t issueText) similarity(other issueText) float64 {
+	score := dice(t.title, other.title)
+	if len(t.description) > 0 && len(other.description) > 0 {
+		score = max(score, (score+dice(t.description, other.description))/2)
+	}
+	return score
+}
+
+// findSimilar returns the open issues at least threshold similar to an issue
+// with title and description, most similar first
+func findSimilar(store *Store, title, description string, threshold float64) []similarIssue {
+	text := newIssueText(title, description)
+	var similar []similarIssue
+	for _, issue := range store.ListIssues() {
+		if issue.Status != "open" {
+			continue
+		}
+		score := text.similarity(newIssueText(issue.Title, issueDescription(issue)))
+		if score >= threshold {
+			similar = append(similar, similarIssue{issue, score})
+		}
+	}
+	sort.SliceStable(similar, func(i, j int) bool { return similar[i].Score > similar[j].Score })
+	if len(similar) > maxSimilar {
+		similar = similar[:maxSimilar]
+	}
+	return similar
+}
+
+// duplicateClusters groups open issues that are at least threshold similar
+// to another in the group. Groups and the issues in them are ordered by ID.
+func duplicateClusters(store *Store, threshold float64) [][]*Issue {
+	var open []*Issue
+	var texts []issueText
+	for _, issue := range store.ListIssues() {
+		if issue.Status == "open" {
+			open = append(open, issue)
+			texts = append(texts, newIssueText(issue.Title, issueDescription(issue)))
+		}
+	}
+
+	// Union the similar pairs, keeping the lowest index as each root
+	parent := make([]int, len(open))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range open {
+		for j := i + 1; j < len(open); j++ {
+			if texts[i].similarity(texts[j]) >= threshold {
+				a, b := find(i), find(j)
+				parent[max(a, b)] = min(a, b)
+			}
+		}
+	}
+
+	groups := make(map[int][]*Issue)
+	for i, issue := range open {
+		root := find(i)
+		groups[root] = append(groups[root], issue)
+	}
+	var clusters [][]*Issue
+	for i := range open {
+		if group := groups[i]; len(group) > 1 {
+			clusters = append(clusters, group)
+		}
+	}
+	return clusters
+}
+
+// printSimilar warns that issues like the one being created already exist
+func printSimilar(w io.Writer, similar []similarIssue, store *Store) error {
+	var b strings.Builder
+	fmt.Fprintln(&b, "warning: similar open issues already exist:")
+	for _, match := range similar {
+		fmt.Fprintf(&b, "  %s %s %s (%d%% similar)\n", store.FormatID(match.Issue.ID), match.Issue.Status, match.Issue.Title, int(match.Score*100))
+	}
+	_, err := fmt.Fprint(w, b.String())
+	return err
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIssueTextSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		similar bool
+	}{
+		{"Write parser", "Write the parser", true},
+		{"Fix typo in README", "fix README typo!", true},
+		{"Add login page", "Add logout button", false},
+		{"Design", "Build", false},
+		{"", "Build", false},
+	}
+	for _, tt := range tests {
+		score := newIssueText(tt.a, "").similarity(newIssueText(tt.b, ""))
+		if (score >= 0.7) != tt.similar {
+			t.Errorf("similarity(%q, %q) = %.2f, want similar = %v", tt.a, tt.b, score, tt.similar)
+		}
+	}
+
+	// Matching descriptions raise the score, missing ones don't lower it
+	title := newIssueText("Parser crashes", "").similarity(newIssueText("Crash on empty input", ""))
+	withDescriptions := newIssueText("Parser crashes", "Reading an empty file panics in the lexer").
+		similarity(newIssueText("Crash on empty input", "Reading an empty file panics in the lexer"))
+	if withDescriptions <= title {
+		t.Errorf("expected matching descriptions to raise %.2f, got %.2f", title, withDescriptions)
+	}
+	if got := newIssueText("Parser crashes", "Reading an empty file").similarity(newIssueText("Parser crashes", "")); got != 1 {
+		t.Errorf("expected a missing description to be ignored, got %.2f", got)
+	}
+}
+
+func TestFindSimilar(t *testing.T) {
+	store := NewStore()
+	parser, _ := store.AddIssue("Write the parser")
+	_, _ = store.AddIssue("Write docs")
+	closed, _ := store.AddIssue("Write a parser")
+	_ = store.CloseIssue(closed.ID, "")
+
+	similar := findSimilar(store, "Write parser", "", 0.7)
+	if len(similar) != 1 || similar[0].Issue != parser {
+		t.Errorf("expected only the open parser issue, got %+v", similar)
+	}
+}
+
+func TestDuplicateClusters(t *testing.T) {
+	store := NewStore()
+	store.Issues = map[string]*Issue{
+		"mint-a": {ID: "mint-a", Title: "Write the parser", Status: "open"},
+		"mint-b": {ID: "mint-b", Title: "Fix README typo", Status: "open"},
+		"mint-c": {ID: "mint-c", Title: "Write parser", Status: "open"},
+		"mint-d": {ID: "mint-d", Title: "Fix typo in README", Status: "open"},
+		"mint-e": {ID: "mint-e", Title: "Release", Status: "open"},
+		"mint-f": {ID: "mint-f", Title: "Write a parser", Status: "closed"},
+	}
+
+	clusters := duplicateClusters(store, 0.7)
+	var got [][]string
+	for _, cluster := range clusters {
+		var ids []string
+		for _, issue := range cluster {
+			ids = append(ids, issue.ID)
+		}
+		got = append(got, ids)
+	}
+	want := [][]string{{"mint-a", "mint-c"}, {"mint-b", "mint-d"}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected clusters %v, got %v", want, got)
+	}
+}
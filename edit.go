@@ -0,0 +1,319 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// editCommentMarker starts each comment in the body of an edit document
+const editCommentMarker = "<!-- comment -->"
+
+// editDocument is the front matter of an issue opened with edit
+type editDocument struct {
+	ID          string   `yaml:"id"`
+	Title       string   `yaml:"title"`
+	Status      string   `yaml:"status"`
+	Resolution  string   `yaml:"resolution"`
+	DuplicateOf string   `yaml:"duplicate_of"`
+	Reason      string   `yaml:"reason"`
+	Labels      []string `yaml:"labels"`
+	Owner       string   `yaml:"owner"`
+	Branch      string   `yaml:"branch"`
+	ExternalRef string   `yaml:"external_ref"`
+	DependsOn   []string `yaml:"depends_on"`
+	Blocks      []string `yaml:"blocks"`
+
+	comments []string
+}
+
+// renderEditDocument renders an issue as Markdown with YAML front matter,
+// with comments in the body
+func renderEditDocument(issue *Issue, store *Store) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.WriteString("# Edit the fields and comments below, then save and quit.\n")
+	b.WriteString("# Each comment follows a " + editCommentMarker + " line. Empty the file to cancel.\n")
+	b.WriteString("# Closed issues take a resolution (" + strings.Join(resolutions, ", ") + ") and a reason.\n")
+
+	fmt.Fprintf(&b, "id: %s\n", issue.ID)
+	writeYAMLField(&b, "title", issue.Title)
+	fmt.Fprintf(&b, "status: %s\n", issue.Status)
+	writeYAMLField(&b, "resolution", issue.Resolution)
+	writeYAMLField(&b, "duplicate_of", issue.DuplicateOf)
+	writeYAMLField(&b, "reason", issue.Reason)
+	writeYAMLList(&b, "labels", issue.Labels)
+	writeYAMLField(&b, "owner", issue.Owner)
+	writeYAMLField(&b, "branch", issue.Branch)
+	writeYAMLField(&b, "external_ref", issue.ExternalRef)
+
+	for _, rel := range []struct {
+		name string
+		ids  []string
+	}{
+		{"depends_on", issue.DependsOn},
+		{"blocks", issue.Blocks},
+	} {
+		if len(rel.ids) == 0 {
+			fmt.Fprintf(&b, "%s: []\n", rel.name)
+			continue
+		}
+		fmt.Fprintf(&b, "%s:\n", rel.name)
+		for _, id := range rel.ids {
+			fmt.Fprintf(&b, "  - %s", id)
+			if related := store.Issues[id]; related != nil {
+				fmt.Fprintf(&b, " # %s", strings.ReplaceAll(related.Title, "\n", " "))
+			}
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("---\n")
+
+	for _, comment := range issue.Comments {
+		fmt.Fprintf(&b, "\n%s\n%s\n", editCommentMarker, comment)
+	}
+	return b.String()
+}
+
+// writeYAMLField writes a quoted scalar so any title round-trips
+func writeYAMLField(b *strings.Builder, name, value string) {
+	out, _ := yaml.MarshalWithOptions(value, yaml.JSON())
+	fmt.Fprintf(b, "%s: %s\n", name, bytes.TrimSpace(out))
+}
+
+func writeYAMLList(b *strings.Builder, name string, values []string) {
+	out, _ := yaml.MarshalWithOptions(values, yaml.JSON(), yaml.Flow(true))
+	if len(values) == 0 {
+		out = []byte("[]")
+	}
+	fmt.Fprintf(b, "%s: %s\n", name, bytes.TrimSpace(out))
+}
+
+// parseEditDocument parses a document written by renderEditDocument
+func parseEditDocument(text string) (*editDocument, error) {
+	text = strings.TrimLeft(text, "\ufeff")
+	rest, ok := strings.CutPrefix(text, "---\n")
+	if !ok {
+		return nil, fmt.Errorf("document must start with a --- line")
+	}
+	frontMatter, body, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		frontMatter, ok = strings.CutSuffix(rest, "\n---")
+		if !ok {
+			return nil, fmt.Errorf("front matter must end with a --- line")
+		}
+	}
+
+	var doc editDocument
+	if err := yaml.UnmarshalWithOptions([]byte(frontMatter), &doc, yaml.DisallowUnknownField()); err != nil {
+		return nil, fmt.Errorf("invalid front matter: %s", yaml.FormatError(err, false, false))
+	}
+
+	// Each marker line starts a comment; text before the first marker
+	// would otherwise be lost, so it's an error
+	var current []string
+	inComment := false
+	flush := func() {
+		if comment := strings.TrimSpace(strings.Join(current, "\n")); inComment && comment != "" {
+			doc.comments = append(doc.comments, comment)
+		}
+		current = nil
+	}
+	for line := range strings.SplitSeq(body, "\n") {
+		if strings.TrimSpace(line) == editCommentMarker {
+			flush()
+			inComment = true
+			continue
+		}
+		if !inComment && strings.TrimSpace(line) != "" {
+			return nil, fmt.Errorf("text after the front matter must follow a %s line", editCommentMarker)
+		}
+		current = append(current, line)
+	}
+	flush()
+	return &doc, nil
+}
+
+// ApplyEdit validates an edited document against the store and applies the
+// changes to issue through the store methods, so relationships stay
+// symmetric. Nothing is changed if validation fails.
+// Returns a description of each change.
+func ApplyEdit(store *Store, issue *Issue, doc *editDocument) ([]string, error) {
+	if doc.ID != issue.ID {
+		return nil, fmt.Errorf("id cannot be changed (was %s)", issue.ID)
+	}
+	title := strings.TrimSpace(doc.Title)
+	if title == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	if doc.Status != "open" && doc.Status != "closed" {
+		return nil, fmt.Errorf("invalid status %q (must be open or closed)", doc.Status)
+	}
+
+	resolveAll := func(field string, refs []string) ([]string, error) {
+		var ids []string
+		for _, ref := range refs {
+			id, err := store.ResolveIssueID(ref)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", field, err)
+			}
+			if id == issue.ID {
+				return nil, fmt.Errorf("%s: issue cannot reference itself", field)
+			}
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	}
+	dependsOn, err := resolveAll("depends_on", doc.DependsOn)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := resolveAll("blocks", doc.Blocks)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range dependsOn {
+		if slices.Contains(blocks, id) {
+			return nil, fmt.Errorf("%s cannot be both a dependency and blocked by this issue", id)
+		}
+	}
+
+	// Check the edited graph for cycles before changing anything
+	graph := store.dependencyGraph()
+	graph[issue.ID] = dependsOn
+	for id, deps := range graph {
+		if id == issue.ID {
+			continue
+		}
+		deps = slices.DeleteFunc(deps, func(depID string) bool { return depID == issue.ID })
+		if slices.Contains(blocks, id) {
+			deps = append(deps, issue.ID)
+		}
+		graph[id] = deps
+	}
+	if cycle := findCycle(graph); cycle != nil {
+		return nil, fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	// Reopening clears the resolution, so it is only checked when closed
+	resolution := strings.TrimSpace(doc.Resolution)
+	reason := strings.TrimSpace(doc.Reason)
+	duplicateOf := strings.TrimSpace(doc.DuplicateOf)
+	if doc.Status == "closed" {
+		if resolution == "" {
+			resolution = resolutionDone
+			if duplicateOf != "" {
+				resolution = resolutionDuplicate
+			}
+		}
+		if duplicateOf, err = store.checkResolution(issue, resolution, duplicateOf); err != nil {
+			return nil, err
+		}
+	}
+	if doc.Status == "closed" && issue.Status != "closed" {
+		var open []string
+		for _, id := range dependsOn {
+			if store.Issues[id].Status == "open" {
+				open = append(open, id)
+			}
+		}
+		if len(open) > 0 {
+			return nil, fmt.Errorf("issue %s depends on open issues: %s (close them first, or remove them from depends_on)", issue.ID, strings.Join(open, ", "))
+		}
+	}
+
+	var changes []string
+	if title != issue.Title {
+		changes = append(changes, fmt.Sprintf("title %q -> %q", issue.Title, title))
+		if err := store.UpdateIssueTitle(issue.ID, title); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := []struct {
+		name    string
+		current *string
+		value   string
+	}{
+		{"owner", &issue.Owner, strings.TrimSpace(doc.Owner)},
+		{"branch", &issue.Branch, strings.TrimSpace(doc.Branch)},
+		{"external ref", &issue.ExternalRef, strings.TrimSpace(doc.ExternalRef)},
+	}
+	for _, field := range fields {
+		if *field.current != field.value {
+			changes = append(changes, fmt.Sprintf("%s %q -> %q", field.name, *field.current, field.value))
+			*field.current = field.value
+			store.touch(issue)
+		}
+	}
+	if !slices.Equal(issue.Labels, doc.Labels) && (len(issue.Labels) > 0 || len(doc.Labels) > 0) {
+		changes = append(changes, fmt.Sprintf("labels [%s] -> [%s]", strings.Join(issue.Labels, ", "), strings.Join(doc.Labels, ", ")))
+		issue.Labels = doc.Labels
+		store.touch(issue)
+	}
+
+	relationships := []struct {
+		current func() []string
+		wanted  []string
+		add     func(issueID, otherID string) error
+		remove  func(issueID, otherID string) error
+		verb    string
+	}{
+		{func() []string { return issue.DependsOn }, dependsOn, store.AddDependency, store.RemoveDependency, "dependency on"},
+		{func() []string { return issue.Blocks }, blocks, store.AddBlocker, store.RemoveBlocker, "blocks"},
+	}
+	for _, rel := range relationships {
+		for _, id := range slices.Clone(rel.current()) {
+			if !slices.Contains(rel.wanted, id) {
+				changes = append(changes, fmt.Sprintf("remove %s %s", rel.verb, id))
+				if err := rel.remove(issue.ID, id); err != nil {
+					return nil, err
+				}
+			}
+		}
+		for _, id := range rel.wanted {
+			if !slices.Contains(rel.current(), id) {
+				changes = append(changes, fmt.Sprintf("add %s %s", rel.verb, id))
+				if err := rel.add(issue.ID, id); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	// Closed after the relationships change, so the guard sees the edited
+	// dependencies
+	switch {
+	case doc.Status == "open" && issue.Status == "closed":
+		changes = append(changes, "status -> open")
+		if err := store.ReopenIssue(issue.ID); err != nil {
+			return nil, err
+		}
+	case doc.Status == "closed" && issue.Status == "open":
+		if _, err := store.CloseIssues(issue.ID, resolution, reason, duplicateOf, false, false); err != nil {
+			return nil, err
+		}
+		changes = append(changes, "status -> closed as "+resolutionText(issue))
+	case doc.Status == "closed" && (resolution != issue.Resolution || reason != issue.Reason || duplicateOf != issue.DuplicateOf):
+		// Changing how an issue was closed keeps when it was closed
+		closedAt := issue.ClosedAt
+		if err := store.CloseIssueAs(issue.ID, resolution, reason, duplicateOf); err != nil {
+			return nil, err
+		}
+		issue.ClosedAt = closedAt
+		changes = append(changes, "closed as "+resolutionText(issue))
+	}
+
+	if !slices.Equal(issue.Comments, doc.comments) && (len(issue.Comments) > 0 || len(doc.comments) > 0) {
+		changes = append(changes, fmt.Sprintf("comments (%d -> %d)", len(issue.Comments), len(doc.comments)))
+		issue.Comments = doc.comments
+		store.touch(issue)
+	}
+
+	return changes, nil
+}
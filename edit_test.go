@@ -0,0 +1,170 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditDocumentRoundTrip(t *testing.T) {
+	store := NewStore()
+	issue1, _ := store.AddIssue(`Fix "quoted": title`)
+	issue2, _ := store.AddIssue("Issue 2")
+	_ = store.AddDependency(issue1.ID, issue2.ID)
+	issue1.Labels = []string{"bug", "ui"}
+	issue1.Comments = []string{"First comment", "Second\n\nwith a blank line"}
+
+	doc, err := parseEditDocument(renderEditDocument(issue1, store))
+	if err != nil {
+		t.Fatalf("parseEditDocument() failed: %v", err)
+	}
+	if doc.ID != issue1.ID || doc.Title != issue1.Title || doc.Status != "open" {
+		t.Errorf("unexpected fields: %+v", doc)
+	}
+	if strings.Join(doc.Labels, ",") != "bug,ui" {
+		t.Errorf("expected labels bug,ui, got %v", doc.Labels)
+	}
+	if len(doc.DependsOn) != 1 || doc.DependsOn[0] != issue2.ID {
+		t.Errorf("expected depends_on [%s], got %v", issue2.ID, doc.DependsOn)
+	}
+	if len(doc.comments) != 2 || doc.comments[1] != "Second\n\nwith a blank line" {
+		t.Errorf("unexpected comments: %q", doc.comments)
+	}
+
+	changes, err := ApplyEdit(store, issue1, doc)
+	if err != nil {
+		t.Fatalf("ApplyEdit() failed: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestParseEditDocumentErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"no front matter", "title: x\n", "must start with"},
+		{"unterminated", "---\ntitle: x\n", "must end with"},
+		{"unknown field", "---\nid: a\npriority: high\n---\n", "invalid front matter"},
+		{"stray text", "---\nid: a\n---\nhello\n", "must follow a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseEditDocument(tt.text)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestApplyEdit(t *testing.T) {
+	store := NewStore()
+	issue1, _ := store.AddIssue("Issue 1")
+	issue2, _ := store.AddIssue("Issue 2")
+	issue3, _ := store.AddIssue("Issue 3")
+	_ = store.AddDependency(issue1.ID, issue2.ID)
+
+	doc := &editDocument{
+		ID:       issue1.ID,
+		Title:    "Renamed",
+		Status:   "closed",
+		Owner:    "alice",
+		Blocks:   []string{issue3.ID},
+		comments: []string{"Done"},
+	}
+	changes, err := ApplyEdit(store, issue1, doc)
+	if err != nil {
+		t.Fatalf("ApplyEdit() failed: %v", err)
+	}
+	if len(changes) == 0 {
+		t.Error("expected changes to be reported")
+	}
+	if issue1.Title != "Renamed" || issue1.Status != "closed" || issue1.Owner != "alice" {
+		t.Errorf("fields not applied: %+v", issue1)
+	}
+	if len(issue1.DependsOn) != 0 || len(issue2.Blocks) != 0 {
+		t.Error("expected dependency on issue 2 to be removed from both sides")
+	}
+	if len(issue3.DependsOn) != 1 || issue3.DependsOn[0] != issue1.ID {
+		t.Errorf("expected issue 3 to depend on issue 1, got %v", issue3.DependsOn)
+	}
+	if len(issue1.Comments) != 1 || issue1.Comments[0] != "Done" {
+		t.Errorf("expected comments to be replaced, got %v", issue1.Comments)
+	}
+}
+
+func TestApplyEditResolution(t *testing.T) {
+	store := NewStore()
+	issue1, _ := store.AddIssue("Issue 1")
+	issue2, _ := store.AddIssue("Issue 2")
+
+	doc, _ := parseEditDocument(renderEditDocument(issue1, store))
+	doc.Status, doc.DuplicateOf, doc.Reason = "closed", issue2.ID[:6], "Same bug"
+	changes, err := ApplyEdit(store, issue1, doc)
+	if err != nil {
+		t.Fatalf("ApplyEdit() failed: %v", err)
+	}
+	if issue1.Resolution != resolutionDuplicate || issue1.DuplicateOf != issue2.ID || issue1.Reason != "Same bug" {
+		t.Errorf("expected a duplicate of %s with the reason, got %+v", issue2.ID, issue1)
+	}
+	if strings.Join(changes, ",") != "status -> closed as duplicate of "+issue2.ID {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+
+	// The rendered resolution round-trips, and changing it keeps the close time
+	closedAt := issue1.ClosedAt
+	doc, _ = parseEditDocument(renderEditDocument(issue1, store))
+	if changes, _ := ApplyEdit(store, issue1, doc); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+	doc.Resolution, doc.DuplicateOf, doc.Reason = "wontfix", "", "Not worth it"
+	if _, err := ApplyEdit(store, issue1, doc); err != nil {
+		t.Fatalf("ApplyEdit() failed: %v", err)
+	}
+	if issue1.Resolution != resolutionWontfix || issue1.DuplicateOf != "" || issue1.Reason != "Not worth it" || !issue1.ClosedAt.Equal(closedAt) {
+		t.Errorf("expected wontfix closed at %v, got %+v", closedAt, issue1)
+	}
+}
+
+func TestApplyEditValidation(t *testing.T) {
+	store := NewStore()
+	issue1, _ := store.AddIssue("Issue 1")
+	issue2, _ := store.AddIssue("Issue 2")
+	issue3, _ := store.AddIssue("Issue 3")
+	issue4, _ := store.AddIssue("Issue 4")
+	_ = store.AddDependency(issue2.ID, issue1.ID)
+	_ = store.AddDependency(issue3.ID, issue2.ID)
+
+	tests := []struct {
+		name string
+		edit func(doc *editDocument)
+		want string
+	}{
+		{"unknown id", func(doc *editDocument) { doc.DependsOn = []string{"zzzz"} }, "depends_on"},
+		{"self reference", func(doc *editDocument) { doc.Blocks = []string{issue1.ID} }, "itself"},
+		{"cycle", func(doc *editDocument) { doc.DependsOn = []string{issue3.ID} }, "dependency cycle"},
+		{"both sides", func(doc *editDocument) { doc.DependsOn = []string{issue2.ID} }, "both a dependency"},
+		{"status", func(doc *editDocument) { doc.Status = "done" }, "invalid status"},
+		{"resolution", func(doc *editDocument) { doc.Status, doc.Resolution = "closed", "fixed" }, "invalid resolution"},
+		{"duplicate", func(doc *editDocument) { doc.Status, doc.Resolution = "closed", "duplicate" }, "requires the issue it duplicates"},
+		{"open dependency", func(doc *editDocument) { doc.Status, doc.DependsOn = "closed", []string{issue4.ID} }, "depends on open issues"},
+		{"empty title", func(doc *editDocument) { doc.Title = " " }, "title is required"},
+		{"changed id", func(doc *editDocument) { doc.ID = "other" }, "id cannot be changed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, _ := parseEditDocument(renderEditDocument(issue1, store))
+			tt.edit(doc)
+			_, err := ApplyEdit(store, issue1, doc)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+			if issue1.Title != "Issue 1" || issue1.Status != "open" || len(issue1.DependsOn) != 0 {
+				t.Errorf("expected issue to be unchanged, got %+v", issue1)
+			}
+		})
+	}
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const htmlStyle = `
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 60rem; margin: 2rem auto; padding: 0 1rem; color: #222; }
+a { color: #5a32a3; text-decoration: none; }
+a:hover { text-decoration: underline; }
+code, .id { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; }
+h2 .badge { font-size: 0.8rem; vertical-align: middle; }
+.badge { display: inline-block; padding: 0.1rem 0.5rem; border-radius: 0.25rem; color: #000; font-weight: 600; }
+.ready { background: #8fd694; }
+.blocked { background: #f08c8c; }
+.closed { background: #ccc; }
+table { border-collapse: collapse; width: 100%; }
+td { padding: 0.25rem 0.5rem; border-bottom: 1px solid #eee; vertical-align: top; }
+dl { display: grid; grid-template-columns: max-content auto; gap: 0.25rem 1rem; }
+dt { font-weight: 600; color: #5a32a3; }
+dd { margin: 0; }
+.comment { white-space: pre-wrap; border-left: 3px solid #ddd; padding: 0.25rem 0.75rem; margin: 0.75rem 0; }
+.graph { overflow-x: auto; border: 1px solid #eee; border-radius: 0.25rem; }
+.graph rect.ready { fill: #8fd694; }
+.graph rect.blocked { fill: #f08c8c; }
+.graph rect.closed { fill: #ccc; }
+.graph text { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 12px; }
+.graph line { stroke: #888; stroke-width: 1.5; }
+`
+
+var htmlTemplates = template.Must(template.New("layout").Parse(`
+{{define "head"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.}}</title>
+<style>` + htmlStyle + `</style>
+</head>
+<body>
+{{end}}
+
+{{define "index"}}{{template "head" "Issues"}}
+<h1>Issues</h1>
+<p>Generated {{.Generated}}</p>
+<h2>Dependencies</h2>
+{{with .Graph}}<div class="graph">
+<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}">
+<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto-start-reverse"><path d="M 0 0 L 10 5 L 0 10 z" fill="#888"/></marker></defs>
+{{range .Edges}}<line x1="{{.X1}}" y1="{{.Y1}}" x2="{{.X2}}" y2="{{.Y2}}" marker-end="url(#arrow)"/>
+{{end}}{{range .Nodes}}<a href="issues/{{.File}}"><title>{{.Title}}</title><rect class="{{.Class}}" x="{{.X}}" y="{{.Y}}" width="{{.Width}}" height="{{.Height}}" rx="4"/><text x="{{.TextX}}" y="{{.TextY}}">{{.Label}}</text></a>
+{{end}}</svg>
+</div>{{else}}<p>No dependencies between issues.</p>{{end}}
+{{range .Sections}}
+<h2><span class="badge {{.Class}}">{{.Title}}</span> {{len .Issues}}</h2>
+{{if .Issues}}<table>
+{{range .Issues}}<tr><td class="id"><a href="issues/{{.File}}">{{.ID}}</a></td><td>{{.Title}}</td><td>{{.Status}}</td></tr>
+{{end}}</table>{{else}}<p>No {{.Class}} issues.</p>{{end}}
+{{end}}
+</body>
+</html>
+{{end}}
+
+{{define "issue"}}{{template "head" .Title}}
+<p><a href="../index.html">&larr; All issues</a></p>
+<h1><span class="id">{{.ID}}</span> {{.Title}}</h1>
+<dl>
+<dt>Status</dt><dd><span class="badge {{.Class}}">{{.Status}}</span>{{with .Resolution}} as {{.}}{{end}}</dd>
+{{with .Reason}}<dt>Reason</dt><dd>{{.}}</dd>{{end}}
+{{with .Labels}}<dt>Labels</dt><dd>{{.}}</dd>{{end}}
+{{with .ExternalRef}}<dt>Ref</dt><dd>{{.}}</dd>{{end}}
+{{with .Owner}}<dt>Owner</dt><dd>{{.}}</dd>{{end}}
+{{with .Branch}}<dt>Branch</dt><dd><code>{{.}}</code></dd>{{end}}
+{{with .Created}}<dt>Created</dt><dd>{{.}}</dd>{{end}}
+{{with .Updated}}<dt>Updated</dt><dd>{{.}}</dd>{{end}}
+{{with .Closed}}<dt>Closed</dt><dd>{{.}}</dd>{{end}}
+</dl>
+{{range .Relationships}}{{if .Issues}}
+<h2>{{.Title}}</h2>
+<table>
+{{range .Issues}}<tr><td class="id">{{if .File}}<a href="{{.File}}">{{.ID}}</a>{{else}}{{.ID}}{{end}}</td><td>{{.Title}}</td><td>{{.Status}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{if .Comments}}<h2>Comments</h2>
+{{range .Comments}}<div class="comment">{{.}}</div>
+{{end}}{{end}}
+</body>
+</html>
+{{end}}
+`))
+
+// htmlIssueRow is an issue as shown in a table row
+type htmlIssueRow struct {
+	ID     string
+	Title  string
+	Status string
+	File   string
+}
+
+// htmlSection is a titled table of issues
+type htmlSection struct {
+	Title  string
+	Class  string
+	Issues []htmlIssueRow
+}
+
+// htmlIssuePage is the data for a single issue page
+type htmlIssuePage struct {
+	ID            string
+	Title         string
+	Status        string
+	Resolution    string
+	Reason        string
+	Class         string
+	Labels        string
+	ExternalRef   string
+	Owner         string
+	Branch        string
+	Created       string
+	Updated       string
+	Closed        string
+	Relationships []htmlSection
+	Comments      []string
+}
+
+// htmlGraph is a laid-out dependency graph rendered as inline SVG
+type htmlGraph struct {
+	Width  int
+	Height int
+	Nodes  []htmlGraphNode
+	Edges  []htmlGraphEdge
+}
+
+type htmlGraphNode struct {
+	ID     string
+	Label  string
+	Title  string
+	Class  string
+	File   string
+	X, Y   int
+	Width  int
+	Height int
+	TextX  int
+	TextY  int
+}
+
+type htmlGraphEdge struct {
+	X1, Y1, X2, Y2 int
+}
+
+// ExportHTML writes a self-contained static site to dir, with an index of
+// every issue, a dependency graph, and a page per issue
+func ExportHTML(store *Store, dir string) error {
+	issuesDir := filepath.Join(dir, "issues")
+	if err := os.MkdirAll(issuesDir, 0o750); err != nil {
+		return err
+	}
+
+	classes := make(map[string]string, len(store.Issues))
+	var sections []htmlSection
+	for _, section := range issueSections(store) {
+		class := strings.ToLower(section.Title)
+		rows := make([]htmlIssueRow, 0, len(section.Issues))
+		for _, issue := range section.Issues {
+			classes[issue.ID] = class
+			rows = append(rows, htmlIssueRow{ID: issue.ID, Title: issue.Title, Status: issue.Status, File: htmlFileName(issue.ID)})
+		}
+		sections = append(sections, htmlSection{Title: section.Title, Class: class, Issues: rows})
+	}
+
+	index := struct {
+		Generated string
+		Graph     *htmlGraph
+		Sections  []htmlSection
+	}{
+		Generated: time.Now().Format(time.DateTime),
+		Graph:     layoutGraph(store, classes),
+		Sections:  sections,
+	}
+	if err := renderHTML(filepath.Join(dir, "index.html"), "index", index); err != nil {
+		return err
+	}
+
+	for _, issue := range store.ListIssues() {
+		page := htmlIssuePage{
+			ID:          issue.ID,
+			Title:       issue.Title,
+			Status:      issue.Status,
+			Resolution:  resolutionText(issue),
+			Reason:      issue.Reason,
+			Class:       classes[issue.ID],
+			Labels:      strings.Join(issue.Labels, ", "),
+			ExternalRef: issue.ExternalRef,
+			Owner:       issue.Owner,
+			Branch:      issue.Branch,
+			Comments:    issue.Comments,
+		}
+		if !issue.CreatedAt.IsZero() {
+			page.Created = issue.CreatedAt.Format(time.DateTime)
+		}
+		if !issue.UpdatedAt.IsZero() {
+			page.Updated = issue.UpdatedAt.Format(time.DateTime)
+		}
+		if !issue.ClosedAt.IsZero() {
+			page.Closed = issue.ClosedAt.Format(time.DateTime)
+		}
+		for _, rel := range []struct {
+			title string
+			ids   []string
+		}{
+			{"Depends on", issue.DependsOn},
+			{"Blocks", issue.Blocks},
+		} {
+			section := htmlSection{Title: rel.title}
+			for _, id := range rel.ids {
+				row := htmlIssueRow{ID: id, Title: "(not found)"}
+				if related := store.Issues[id]; related != nil {
+					row = htmlIssueRow{ID: id, Title: related.Title, Status: related.Status, File: htmlFileName(id)}
+				}
+				section.Issues = append(section.Issues, row)
+			}
+			page.Relationships = append(page.Relationships, section)
+		}
+
+		if err := renderHTML(filepath.Join(issuesDir, htmlFileName(issue.ID)), "issue", page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderHTML executes the named template into a file
+func renderHTML(path, name string, data any) error {
+	// #nosec G304 -- path is built from the user-chosen output directory
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := htmlTemplates.ExecuteTemplate(f, name, data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// htmlFileName returns the page file name for an issue ID
+func htmlFileName(id string) string {
+	return strings.NewReplacer("/", "_", "\\", "_").Replace(id) + ".html"
+}
+
+// layoutGraph lays out every issue that has a relationship in columns by
+// dependency depth, so blockers are always left of the issues they block
+// Returns nil if no issues are related
+func layoutGraph(store *Store, classes map[string]string) *htmlGraph {
+	const (
+		nodeWidth  = 160
+		nodeHeight = 28
+		colGap     = 60
+		rowGap     = 16
+		margin     = 16
+		charWidth  = 7
+	)
+
+	var ids []string
+	for _, issue := range store.ListIssues() {
+		if len(issue.DependsOn) > 0 || len(issue.Blocks) > 0 {
+			ids = append(ids, issue.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// Longest path from a root; bounded by the node count so cycles terminate
+	depth := make(map[string]int, len(ids))
+	for range ids {
+		changed := false
+		for _, id := range ids {
+			for _, depID := range store.Issues[id].DependsOn {
+				if _, ok := store.Issues[depID]; !ok {
+					continue
+				}
+				if d := depth[depID] + 1; d > depth[id] && d < len(ids) {
+					depth[id] = d
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	columns := make(map[int][]string)
+	maxDepth, maxRows := 0, 0
+	for _, id := range ids {
+		d := depth[id]
+		columns[d] = append(columns[d], id)
+		maxDepth = max(maxDepth, d)
+		maxRows = max(maxRows, len(columns[d]))
+	}
+
+	graph := &htmlGraph{
+		Width:  2*margin + (maxDepth+1)*nodeWidth + maxDepth*colGap,
+		Height: 2*margin + maxRows*nodeHeight + (maxRows-1)*rowGap,
+	}
+	positions := make(map[string]htmlGraphNode, len(ids))
+	for d := 0; d <= maxDepth; d++ {
+		column := columns[d]
+		sort.Strings(column)
+		for row, id := range column {
+			issue := store.Issues[id]
+			label := id
+			if maxChars := (nodeWidth - 16) / charWidth; len(label) > maxChars {
+				label = label[:maxChars-1] + "…"
+			}
+			node := htmlGraphNode{
+				ID:     id,
+				Label:  label,
+				Title:  id + " " + issue.Title,
+				Class:  classes[id],
+				File:   htmlFileName(id),
+				X:      margin + d*(nodeWidth+colGap),
+				Y:      margin + row*(nodeHeight+rowGap),
+				Width:  nodeWidth,
+				Height: nodeHeight,
+			}
+			node.TextX = node.X + 8
+			node.TextY = node.Y + nodeHeight/2 + 4
+			positions[id] = node
+			graph.Nodes = append(graph.Nodes, node)
+		}
+	}
+
+	for _, id := range ids {
+		to := positions[id]
+		for _, depID := range store.Issues[id].DependsOn {
+			from, ok := positions[depID]
+			if !ok {
+				continue
+			}
+			graph.Edges = append(graph.Edges, htmlGraphEdge{
+				X1: from.X + from.Width,
+				Y1: from.Y + from.Height/2,
+				X2: to.X,
+				Y2: to.Y + to.Height/2,
+			})
+		}
+	}
+
+	return graph
+}
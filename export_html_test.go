@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportHTML(t *testing.T) {
+	store := NewStore()
+	blocker, _ := store.AddIssue("Set up CI")
+	blocked, _ := store.AddIssue("Write <tests>")
+	_ = store.AddDependency(blocked.ID, blocker.ID)
+	_ = store.AddComment(blocked.ID, "Needs <script>alert(1)</script> escaping")
+
+	dir := t.TempDir()
+	if err := ExportHTML(store, dir); err != nil {
+		t.Fatalf("ExportHTML() failed: %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read index: %v", err)
+	}
+	indexHTML := string(index)
+	for _, want := range []string{
+		"<svg",
+		`href="issues/` + blocker.ID + `.html"`,
+		"Write &lt;tests&gt;",
+		`class="badge ready">Ready`,
+		`class="badge blocked">Blocked`,
+		"<line ",
+	} {
+		if !strings.Contains(indexHTML, want) {
+			t.Errorf("expected index to contain %q", want)
+		}
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "issues", blocked.ID+".html"))
+	if err != nil {
+		t.Fatalf("failed to read issue page: %v", err)
+	}
+	pageHTML := string(page)
+	if !strings.Contains(pageHTML, `<a href="`+blocker.ID+`.html">`+blocker.ID+`</a>`) {
+		t.Errorf("expected issue page to link to its dependency, got: %s", pageHTML)
+	}
+	if strings.Contains(pageHTML, "<script>") {
+		t.Error("expected comments to be escaped")
+	}
+	if !strings.Contains(pageHTML, `href="../index.html"`) {
+		t.Error("expected issue page to link back to the index")
+	}
+}
+
+func TestExportHTML_NoRelationships(t *testing.T) {
+	store := NewStore()
+	_, _ = store.AddIssue("Lonely issue")
+
+	dir := t.TempDir()
+	if err := ExportHTML(store, dir); err != nil {
+		t.Fatalf("ExportHTML() failed: %v", err)
+	}
+
+	index, _ := os.ReadFile(filepath.Join(dir, "index.html"))
+	if !strings.Contains(string(index), "No dependencies between issues.") {
+		t.Errorf("expected placeholder for empty graph, got: %s", index)
+	}
+}
+
+func TestLayoutGraph(t *testing.T) {
+	store := NewStore()
+	a, _ := store.AddIssue("A")
+	b, _ := store.AddIssue("B")
+	c, _ := store.AddIssue("C")
+	_, _ = store.AddIssue("Unrelated")
+	_ = store.AddDependency(b.ID, a.ID)
+	_ = store.AddDependency(c.ID, b.ID)
+	_ = store.AddDependency(c.ID, a.ID)
+
+	graph := layoutGraph(store, map[string]string{})
+	if graph == nil {
+		t.Fatal("expected a graph")
+	}
+	if len(graph.Nodes) != 3 {
+		t.Fatalf("expected 3 nodes, got %d", len(graph.Nodes))
+	}
+	if len(graph.Edges) != 3 {
+		t.Errorf("expected 3 edges, got %d", len(graph.Edges))
+	}
+
+	x := make(map[string]int)
+	for _, node := range graph.Nodes {
+		x[node.ID] = node.X
+	}
+	if x[a.ID] >= x[b.ID] || x[b.ID] >= x[c.ID] {
+		t.Errorf("expected columns ordered by depth, got %v", x)
+	}
+}
+
+func TestLayoutGraph_Cycle(t *testing.T) {
+	store := NewStore()
+	a, _ := store.AddIssue("A")
+	b, _ := store.AddIssue("B")
+	_ = store.AddDependency(b.ID, a.ID)
+	_ = store.AddDependency(a.ID, b.ID)
+
+	graph := layoutGraph(store, map[string]string{})
+	if graph == nil || len(graph.Nodes) != 2 {
+		t.Fatal("expected cyclic graph to be laid out")
+	}
+}
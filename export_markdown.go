@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// issueSection is a titled group of issues, like the sections of list
+type issueSection struct {
+	Title  string
+	Issues []*Issue
+}
+
+// issueSections returns the Ready, Blocked, and Closed sections of the store
+func issueSections(store *Store) []issueSection {
+	ready, blocked, closed := partitionIssues(store)
+	return []issueSection{
+		{"Ready", ready},
+		{"Blocked", blocked},
+		{"Closed", closed},
+	}
+}
+
+// ExportMarkdown writes a human-readable Markdown document describing every
+// issue in the store, with anchor links between related issues
+func ExportMarkdown(store *Store, w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintln(&b, "# Issues")
+	fmt.Fprintln(&b)
+
+	sections := issueSections(store)
+
+	// Summary with links to every issue
+	for _, section := range sections {
+		fmt.Fprintf(&b, "- [%s](#%s) (%d)\n", section.Title, strings.ToLower(section.Title), len(section.Issues))
+	}
+
+	for _, section := range sections {
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "## %s\n", section.Title)
+		fmt.Fprintln(&b)
+		if len(section.Issues) == 0 {
+			fmt.Fprintf(&b, "_No %s issues._\n", strings.ToLower(section.Title))
+			continue
+		}
+		for _, issue := range section.Issues {
+			fmt.Fprintf(&b, "- [%s](#%s) %s\n", issue.ID, issue.ID, markdownInline(issue.Title))
+		}
+		for _, issue := range section.Issues {
+			fmt.Fprintln(&b)
+			writeMarkdownIssue(&b, issue, store)
+		}
+	}
+
+	_, err := fmt.Fprint(w, b.String())
+	return err
+}
+
+// writeMarkdownIssue writes the details of a single issue
+func writeMarkdownIssue(b *strings.Builder, issue *Issue, store *Store) {
+	fmt.Fprintf(b, "<a id=\"%s\"></a>\n\n", issue.ID)
+	fmt.Fprintf(b, "### %s: %s\n", issue.ID, markdownInline(issue.Title))
+	fmt.Fprintln(b)
+	if issue.Status == "closed" && issue.Resolution != "" {
+		fmt.Fprintf(b, "- **Status:** %s as %s\n", issue.Status, resolutionText(issue))
+	} else {
+		fmt.Fprintf(b, "- **Status:** %s\n", issue.Status)
+	}
+	if issue.Reason != "" {
+		fmt.Fprintf(b, "- **Reason:** %s\n", markdownInline(issue.Reason))
+	}
+	if len(issue.Labels) > 0 {
+		fmt.Fprintf(b, "- **Labels:** %s\n", markdownInline(strings.Join(issue.Labels, ", ")))
+	}
+	if issue.ExternalRef != "" {
+		fmt.Fprintf(b, "- **Ref:** %s\n", markdownInline(issue.ExternalRef))
+	}
+	if issue.Owner != "" {
+		fmt.Fprintf(b, "- **Owner:** %s\n", markdownInline(issue.Owner))
+	}
+	if issue.Branch != "" {
+		fmt.Fprintf(b, "- **Branch:** `%s`\n", issue.Branch)
+	}
+	if !issue.CreatedAt.IsZero() {
+		fmt.Fprintf(b, "- **Created:** %s\n", issue.CreatedAt.Format(time.DateTime))
+	}
+	if !issue.UpdatedAt.IsZero() {
+		fmt.Fprintf(b, "- **Updated:** %s\n", issue.UpdatedAt.Format(time.DateTime))
+	}
+	if !issue.ClosedAt.IsZero() {
+		fmt.Fprintf(b, "- **Closed:** %s\n", issue.ClosedAt.Format(time.DateTime))
+	}
+
+	relationships := []struct {
+		label string
+		ids   []string
+	}{
+		{"Depends on", issue.DependsOn},
+		{"Blocks", issue.Blocks},
+	}
+	for _, rel := range relationships {
+		if len(rel.ids) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "- **%s:**\n", rel.label)
+		for _, id := range rel.ids {
+			if related := store.Issues[id]; related != nil {
+				fmt.Fprintf(b, "  - [%s](#%s) %s (%s)\n", id, id, markdownInline(related.Title), related.Status)
+			} else {
+				fmt.Fprintf(b, "  - %s (not found)\n", id)
+			}
+		}
+	}
+
+	if len(issue.Comments) > 0 {
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "**Comments**")
+		for _, comment := range issue.Comments {
+			fmt.Fprintln(b)
+			for line := range strings.SplitSeq(comment, "\n") {
+				fmt.Fprintf(b, "> %s\n", line)
+			}
+		}
+	}
+}
+
+// markdownInline escapes characters that would otherwise start Markdown
+// formatting in a single line of text
+func markdownInline(s string) string {
+	replacer := strings.NewReplacer(
+		"\\", "\\\\",
+		"*", "\\*",
+		"_", "\\_",
+		"[", "\\[",
+		"]", "\\]",
+		"<", "&lt;",
+		"`", "\\`",
+		"\n", " ",
+	)
+	return replacer.Replace(s)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExportMarkdown(t *testing.T) {
+	store := NewStore()
+	blocker, _ := store.AddIssue("Set up *CI*")
+	blocked, _ := store.AddIssue("Write tests")
+	done, _ := store.AddIssue("Pick a framework")
+	_ = store.AddDependency(blocked.ID, blocker.ID)
+	_ = store.AddComment(blocker.ID, "First line\nSecond line")
+	_ = store.CloseIssue(done.ID, "")
+
+	var buf bytes.Buffer
+	if err := ExportMarkdown(store, &buf); err != nil {
+		t.Fatalf("ExportMarkdown() failed: %v", err)
+	}
+	output := buf.String()
+
+	readyPos := strings.Index(output, "## Ready")
+	blockedPos := strings.Index(output, "## Blocked")
+	closedPos := strings.Index(output, "## Closed")
+	if readyPos < 0 || blockedPos < readyPos || closedPos < blockedPos {
+		t.Fatalf("expected Ready, Blocked, and Closed sections in order, got: %s", output)
+	}
+
+	expected := []string{
+		"- [Ready](#ready) (1)",
+		`<a id="` + blocker.ID + `"></a>`,
+		"### " + blocker.ID + ": Set up \\*CI\\*",
+		"- [" + blocked.ID + "](#" + blocked.ID + ") Write tests",
+		"  - [" + blocker.ID + "](#" + blocker.ID + ") Set up \\*CI\\* (open)",
+		"> First line\n> Second line",
+		"- **Status:** closed",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got: %s", want, output)
+		}
+	}
+
+	// The blocked issue is described in the Blocked section
+	if pos := strings.Index(output, "### "+blocked.ID); pos < blockedPos || pos > closedPos {
+		t.Errorf("expected %s to be detailed in the Blocked section", blocked.ID)
+	}
+}
+
+func TestExportMarkdown_EmptySections(t *testing.T) {
+	store := NewStore()
+
+	var buf bytes.Buffer
+	if err := ExportMarkdown(store, &buf); err != nil {
+		t.Fatalf("ExportMarkdown() failed: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"_No ready issues._", "_No blocked issues._", "_No closed issues._"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got: %s", want, output)
+		}
+	}
+}
@@ -35,20 +35,81 @@ func formatRelativeTime(t time.Time) string {
 	return fmt.Sprintf("%dd ago", days)
 }
 
+// changedMarker follows lines about issues that changed since watch last
+// drew them
+const changedMarker = " \033[38;5;3m← changed\033[0m"
+
+// changedSuffix returns changedMarker if id is in changed
+func changedSuffix(changed map[string]bool, id string) string {
+	if changed[id] {
+		return changedMarker
+	}
+	return ""
+}
+
+// statusLabel is an issue's status in lists: the resolution for issues
+// closed as anything but done
+func statusLabel(issue *Issue) string {
+	if issue.Status == "closed" && issue.Resolution != "" && issue.Resolution != resolutionDone {
+		return issue.Resolution
+	}
+	return issue.Status
+}
+
+// resolutionText describes how a closed issue was resolved, like "wontfix"
+// or "duplicate of mint-ab"
+func resolutionText(issue *Issue) string {
+	if issue.Resolution == resolutionDuplicate {
+		return issue.Resolution + " of " + issue.DuplicateOf
+	}
+	return issue.Resolution
+}
+
 // PrintIssueDetails prints full issue details including ID, Title, Status,
 // Dependencies, Blocks, and Comments
 func PrintIssueDetails(w io.Writer, issue *Issue, store *Store) error {
+	return printIssueDetails(w, issue, store, nil)
+}
+
+// printIssueDetails prints issue details, marking the issue's status and
+// related issues that are in changed
+func printIssueDetails(w io.Writer, issue *Issue, store *Store, changed map[string]bool) error {
 	var b strings.Builder
 	fmt.Fprintln(&b)
 	fmt.Fprintf(&b, "\033[1m\033[38;5;5mID\033[0m      %s\n", store.FormatID(issue.ID))
 	fmt.Fprintf(&b, "\033[1m\033[38;5;5mTitle\033[0m   %s\n", issue.Title)
-	fmt.Fprintf(&b, "\033[1m\033[38;5;5mStatus\033[0m  %s\n", issue.Status)
+	status := issue.Status
+	if issue.Status == "closed" && issue.Resolution != "" {
+		status += " as " + issue.Resolution
+		if issue.DuplicateOf != "" {
+			status += " of " + store.FormatID(issue.DuplicateOf)
+		}
+	}
+	fmt.Fprintf(&b, "\033[1m\033[38;5;5mStatus\033[0m  %s%s\n", status, changedSuffix(changed, issue.ID))
+	if issue.Reason != "" {
+		fmt.Fprintf(&b, "\033[1m\033[38;5;5mReason\033[0m  %s\n", issue.Reason)
+	}
+	if len(issue.Labels) > 0 {
+		fmt.Fprintf(&b, "\033[1m\033[38;5;5mLabels\033[0m  %s\n", strings.Join(issue.Labels, ", "))
+	}
+	if issue.ExternalRef != "" {
+		fmt.Fprintf(&b, "\033[1m\033[38;5;5mRef\033[0m     %s\n", issue.ExternalRef)
+	}
+	if issue.Owner != "" {
+		fmt.Fprintf(&b, "\033[1m\033[38;5;5mOwner\033[0m   %s\n", issue.Owner)
+	}
+	if issue.Branch != "" {
+		fmt.Fprintf(&b, "\033[1m\033[38;5;5mBranch\033[0m  %s\n", issue.Branch)
+	}
 	if !issue.CreatedAt.IsZero() {
 		fmt.Fprintf(&b, "\033[1m\033[38;5;5mCreated\033[0m %s (%s)\n", issue.CreatedAt.Format(time.DateTime), formatRelativeTime(issue.CreatedAt))
 	}
 	if !issue.UpdatedAt.IsZero() {
 		fmt.Fprintf(&b, "\033[1m\033[38;5;5mUpdated\033[0m %s (%s)\n", issue.UpdatedAt.Format(time.DateTime), formatRelativeTime(issue.UpdatedAt))
 	}
+	if !issue.ClosedAt.IsZero() {
+		fmt.Fprintf(&b, "\033[1m\033[38;5;5mClosed\033[0m  %s (%s)\n", issue.ClosedAt.Format(time.DateTime), formatRelativeTime(issue.ClosedAt))
+	}
 	if len(issue.DependsOn) > 0 || len(issue.Blocks) > 0 || len(issue.Comments) > 0 {
 		fmt.Fprintln(&b)
 	}
@@ -59,7 +120,7 @@ func PrintIssueDetails(w io.Writer, issue *Issue, store *Store) error {
 			if err != nil {
 				fmt.Fprintf(&b, "  %s (not found)\n", store.FormatID(depID))
 			} else {
-				fmt.Fprintf(&b, "  %s %s %s\n", store.FormatID(dep.ID), dep.Status, dep.Title)
+				fmt.Fprintf(&b, "  %s %s %s%s\n", store.FormatID(dep.ID), statusLabel(dep), dep.Title, changedSuffix(changed, dep.ID))
 			}
 		}
 	}
@@ -74,7 +135,7 @@ func PrintIssueDetails(w io.Writer, issue *Issue, store *Store) error {
 			if err != nil {
 				fmt.Fprintf(&b, "  %s (not found)\n", store.FormatID(blockID))
 			} else {
-				fmt.Fprintf(&b, "  %s %s %s\n", store.FormatID(blocked.ID), blocked.Status, blocked.Title)
+				fmt.Fprintf(&b, "  %s %s %s%s\n", store.FormatID(blocked.ID), statusLabel(blocked), blocked.Title, changedSuffix(changed, blocked.ID))
 			}
 		}
 	}
@@ -95,13 +156,33 @@ func PrintIssueDetails(w io.Writer, issue *Issue, store *Store) error {
 
 // printIssueList prints a list of issues with aligned formatting
 func printIssueList(w io.Writer, issues []*Issue, maxIDLen int, store *Store) error {
+	return printChangedIssueList(w, issues, maxIDLen, store, nil)
+}
+
+// printChangedIssueList prints a list of issues, marking those in changed
+func printChangedIssueList(w io.Writer, issues []*Issue, maxIDLen int, store *Store, changed map[string]bool) error {
 	for _, issue := range issues {
 		formattedID := store.FormatID(issue.ID)
 		// Pad shorter IDs so status words align across all issues
 		padding := strings.Repeat(" ", 1+maxIDLen-len(issue.ID))
-		if _, err := fmt.Fprintf(w, "   %s%s%s %s\n", formattedID, padding, issue.Status, issue.Title); err != nil {
+		if _, err := fmt.Fprintf(w, "   %s%s%s %s%s\n", formattedID, padding, statusLabel(issue), issue.Title, changedSuffix(changed, issue.ID)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// printNowReady lists the issues a close or delete unblocked, if any
+func printNowReady(w io.Writer, issues []*Issue, store *Store) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintln(&b, "\033[1m\033[38;5;5mNow ready\033[0m")
+	for _, issue := range issues {
+		fmt.Fprintf(&b, "  %s %s %s\n", store.FormatID(issue.ID), issue.Status, issue.Title)
+	}
+	fmt.Fprintln(&b)
+	_, err := fmt.Fprint(w, b.String())
+	return err
+}
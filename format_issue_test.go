@@ -531,3 +531,43 @@ func TestPrintIssueDetails_WithRelativeTimestamps(t *testing.T) {
 		t.Errorf("expected 'Updated' in output, got: %s", output)
 	}
 }
+
+func TestPrintIssueDetails_WithLabelsAndRef(t *testing.T) {
+	store := NewStore()
+	issue, _ := store.AddIssue("Imported issue")
+	issue.Labels = []string{"bug", "ui"}
+	issue.ExternalRef = "https://github.com/acme/widgets/issues/9"
+
+	var buf bytes.Buffer
+	if err := PrintIssueDetails(&buf, issue, store); err != nil {
+		t.Fatalf("PrintIssueDetails() failed: %v", err)
+	}
+
+	output := stripANSI(buf.String())
+	if !strings.Contains(output, "Labels  bug, ui") {
+		t.Errorf("expected output to contain 'Labels  bug, ui', got: %s", output)
+	}
+	if !strings.Contains(output, "Ref     https://github.com/acme/widgets/issues/9") {
+		t.Errorf("expected output to contain external ref, got: %s", output)
+	}
+}
+
+func TestPrintIssueDetails_WithOwnerAndBranch(t *testing.T) {
+	store := NewStore()
+	issue, _ := store.AddIssue("Started issue")
+	issue.Owner = "Alice"
+	issue.Branch = issue.ID + "-started-issue"
+
+	var buf bytes.Buffer
+	if err := PrintIssueDetails(&buf, issue, store); err != nil {
+		t.Fatalf("PrintIssueDetails() failed: %v", err)
+	}
+
+	output := stripANSI(buf.String())
+	if !strings.Contains(output, "Owner   Alice") {
+		t.Errorf("expected output to contain 'Owner   Alice', got: %s", output)
+	}
+	if !strings.Contains(output, "Branch  "+issue.Branch) {
+		t.Errorf("expected output to contain branch, got: %s", output)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// runGit runs git with args in dir and returns its trimmed output
+func runGit(dir string, args ...string) (string, error) {
+	// #nosec G204 -- git is invoked directly with fixed subcommands, not via a shell
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// currentBranch returns the branch checked out in dir, or "" when HEAD is
+// detached
+func currentBranch(dir string) (string, error) {
+	if _, err := runGit(dir, "rev-parse", "--git-dir"); err != nil {
+		return "", err
+	}
+	branch, err := runGit(dir, "symbolic-ref", "--short", "--quiet", "HEAD")
+	if err != nil {
+		return "", nil
+	}
+	return branch, nil
+}
+
+// branchExists reports whether a local branch exists in the repository at dir
+func branchExists(dir, branch string) bool {
+	_, err := runGit(dir, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
+
+// currentUser returns the git user name, falling back to $USER
+func currentUser(dir string) string {
+	if name, err := runGit(dir, "config", "user.name"); err == nil && name != "" {
+		return name
+	}
+	return os.Getenv("USER")
+}
+
+// maxSlugLength bounds the title part of a branch name
+const maxSlugLength = 40
+
+// branchName builds a branch name from an issue ID and a slug of its title,
+// e.g. "mint-a8-fix-parser-crash"
+func branchName(issue *Issue) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(issue.Title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		// Cut at a word boundary unless the first word is too long
+		cut := slug[:maxSlugLength]
+		if slug[maxSlugLength] != '-' {
+			if i := strings.LastIndex(cut, "-"); i > 0 {
+				cut = cut[:i]
+			}
+		}
+		slug = cut
+	}
+	if slug == "" {
+		return issue.ID
+	}
+	return issue.ID + "-" + slug
+}
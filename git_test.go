@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestBranchName(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Fix parser crash", "mint-a8-fix-parser-crash"},
+		{"  Handle `--force` (again)! ", "mint-a8-handle-force-again"},
+		{"Ünïcode & symbols", "mint-a8-n-code-symbols"},
+		{"!!!", "mint-a8"},
+		{"Support closing issues with dependencies and cascading deletes", "mint-a8-support-closing-issues-with-dependencies"},
+		{"Support closing issues with dependency chains", "mint-a8-support-closing-issues-with-dependency"},
+	}
+	for _, tt := range tests {
+		got := branchName(&Issue{ID: "mint-a8", Title: tt.title})
+		if got != tt.want {
+			t.Errorf("branchName(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestCurrentBranch(t *testing.T) {
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	if _, err := runGit(dir, "checkout", "-q", "-b", "topic"); err != nil {
+		t.Fatal(err)
+	}
+
+	branch, err := currentBranch(dir)
+	if err != nil {
+		t.Fatalf("currentBranch failed: %v", err)
+	}
+	if branch != "topic" {
+		t.Errorf("expected topic, got %q", branch)
+	}
+
+	if _, err := currentBranch(t.TempDir()); err == nil {
+		t.Error("expected error outside a git repository")
+	}
+}
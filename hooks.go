@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// hooksDir is where hooks live, relative to the project root
+const hooksDir = ".mint/hooks"
+
+// preCloseHook runs before a save that closes an issue and can stop it
+const preCloseHook = "pre-close"
+
+// Events passed to hooks. After a save, on-<event> runs for each one.
+const (
+	eventCreate = "create"
+	eventClose  = "close"
+	eventReopen = "reopen"
+	eventReady  = "ready"
+)
+
+// hookEvent is something that happened to an issue between loading and
+// saving the store
+type hookEvent struct {
+	Name  string
+	Issue *Issue
+}
+
+// hookState is the part of an issue events are derived from
+type hookState struct {
+	status string
+	ready  bool
+}
+
+func (s *Store) hookStates() map[string]hookState {
+	states := make(map[string]hookState, len(s.Issues))
+	for id, issue := range s.Issues {
+		states[id] = hookState{status: issue.Status, ready: issue.Status == "open" && s.IsReady(issue)}
+	}
+	return states
+}
+
+// lifecycleEvents returns the events for issues that were created, closed,
+// reopened, or became ready since the store was loaded, by issue ID. An
+// issue created already closed gets a close event after its create event.
+func (s *Store) lifecycleEvents() []hookEvent {
+	var events []hookEvent
+	for id, now := range s.hookStates() {
+		issue := s.Issues[id]
+		before, existed := s.loaded[id]
+		switch {
+		case !existed:
+			events = append(events, hookEvent{eventCreate, issue})
+			if now.status == "closed" {
+				events = append(events, hookEvent{eventClose, issue})
+			}
+		case before.status != "closed" && now.status == "closed":
+			events = append(events, hookEvent{eventClose, issue})
+		case before.status == "closed" && now.status != "closed":
+			events = append(events, hookEvent{eventReopen, issue})
+		case !before.ready && now.ready:
+			events = append(events, hookEvent{eventReady, issue})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Issue.ID < events[j].Issue.ID })
+	return events
+}
+
+// hookRejectedError is returned when the pre-close hook stops a save
+type hookRejectedError struct {
+	issueID string
+	reason  string
+}
+
+func (e *hookRejectedError) Error() string {
+	return fmt.Sprintf("closing %s was rejected by the %s hook: %s", e.issueID, preCloseHook, e.reason)
+}
+
+// saveStore saves the store and runs the hooks for what changed since it
+// was loaded. The pre-close hook runs first and can stop the save. Hooks
+// that fail after the save are reported to errW, since the save stands.
+func saveStore(store *Store, filePath string, errW io.Writer) error {
+	root, err := projectRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	timeout := time.Duration(cfg.Int("hooks.timeout")) * time.Second
+
+	events := store.lifecycleEvents()
+	for _, event := range events {
+		if event.Name != eventClose {
+			continue
+		}
+		output, err := runHook(root, preCloseHook, store, event, timeout)
+		if err != nil {
+			reason := strings.TrimSpace(string(output))
+			if reason == "" {
+				reason = err.Error()
+			}
+			return &hookRejectedError{issueID: event.Issue.ID, reason: reason}
+		}
+		_, _ = errW.Write(output)
+	}
+
+	if err := store.Save(filePath); err != nil {
+		return err
+	}
+	store.loaded = store.hookStates()
+
+	for _, event := range events {
+		name := "on-" + event.Name
+		output, err := runHook(root, name, store, event, timeout)
+		_, _ = errW.Write(output)
+		if err != nil {
+			_, _ = fmt.Fprintf(errW, "warning: %s hook failed for %s: %v\n", name, event.Issue.ID, err)
+		}
+	}
+	return nil
+}
+
+// hookPayload is the JSON a hook reads from stdin
+type hookPayload struct {
+	Event string   `json:"event"`
+	Issue apiIssue `json:"issue"`
+}
+
+// runHook runs the hook called name, if there is one, with the event on
+// stdin and returns its combined output. Hooks run in the project root and
+// are stopped after timeout.
+func runHook(root, name string, store *Store, event hookEvent, timeout time.Duration) ([]byte, error) {
+	path := filepath.Join(root, hooksDir, name)
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() || info.Mode()&0o111 == 0 {
+		return nil, fmt.Errorf("%s is not executable", path)
+	}
+
+	payload, err := json.Marshal(hookPayload{Event: event.Name, Issue: toAPIIssue(store, event.Issue)})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	// #nosec G204 -- hooks are scripts the project put in its hooks directory
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Dir = root
+	cmd.Env = append(os.Environ(), "MINT_EVENT="+event.Name, "MINT_ISSUE_ID="+event.Issue.ID)
+	cmd.Stdin = bytes.NewReader(payload)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return output.Bytes(), fmt.Errorf("timed out after %s", timeout)
+	}
+	return output.Bytes(), err
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeHook writes an executable hook script to the project at root
+func writeHook(t *testing.T, root, name, script string) {
+	t.Helper()
+	dir := filepath.Join(root, hooksDir)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	// #nosec G306 -- hooks must be executable
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script+"\n"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLifecycleEvents(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "mint-issues.yaml")
+	store := NewStore()
+	parser, _ := store.AddIssue("Write parser")
+	docs, _ := store.AddIssue("Write docs")
+	old, _ := store.AddIssue("Old idea")
+	_ = store.AddDependency(docs.ID, parser.ID)
+	_ = store.CloseIssue(old.ID, "")
+	if err := store.Save(filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	store, _ = LoadStore(filePath)
+	if events := store.lifecycleEvents(); len(events) != 0 {
+		t.Fatalf("expected no events right after loading, got %+v", events)
+	}
+
+	_ = store.CloseIssue(parser.ID, "")
+	_ = store.ReopenIssue(old.ID)
+	added, _ := store.AddIssue("New idea")
+
+	got := make(map[string]string)
+	for _, event := range store.lifecycleEvents() {
+		got[event.Issue.ID] = event.Name
+	}
+	want := map[string]string{parser.ID: eventClose, docs.ID: eventReady, old.ID: eventReopen, added.ID: eventCreate}
+	if len(got) != len(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	for id, name := range want {
+		if got[id] != name {
+			t.Errorf("expected %s event for %s, got %q", name, id, got[id])
+		}
+	}
+}
+
+func TestHooksRunAfterSave(t *testing.T) {
+	root := t.TempDir()
+	filePath := filepath.Join(root, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	writeHook(t, root, "on-create", `cat >> events.log; echo >> events.log`)
+	writeHook(t, root, "on-close", `echo "$MINT_EVENT $MINT_ISSUE_ID" >> events.log`)
+
+	if _, err := runMint(t, "create", "Write parser"); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	store, _ := LoadStore(filePath)
+	issue := store.ListIssues()[0]
+
+	data, err := os.ReadFile(filepath.Join(root, "events.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload struct {
+		Event string `json:"event"`
+		Issue struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"issue"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("expected the event as JSON, got %q: %v", data, err)
+	}
+	if payload.Event != "create" || payload.Issue.ID != issue.ID || payload.Issue.Title != "Write parser" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+
+	if _, err := runMint(t, "close", issue.ID); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Join(root, "events.log"))
+	if !strings.HasSuffix(string(data), "close "+issue.ID+"\n") {
+		t.Errorf("expected the close hook to run, got %q", data)
+	}
+}
+
+func TestPreCloseHookRejectsClose(t *testing.T) {
+	root := t.TempDir()
+	filePath := filepath.Join(root, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	writeHook(t, root, "pre-close", `echo "tests are failing" >&2; exit 1`)
+
+	store := NewStore()
+	issue, _ := store.AddIssue("Write parser")
+	if err := store.Save(filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := runMint(t, "close", issue.ID)
+	if err == nil || !strings.Contains(err.Error(), "closing "+issue.ID+" was rejected by the pre-close hook: tests are failing") {
+		t.Fatalf("expected the close to be rejected, got %v", err)
+	}
+	store, _ = LoadStore(filePath)
+	if store.Issues[issue.ID].Status != "open" {
+		t.Errorf("expected the issue to stay open")
+	}
+
+	// Other changes don't run it
+	if _, err := runMint(t, "update", issue.ID, "--title", "Write the parser"); err != nil {
+		t.Errorf("expected update to succeed, got %v", err)
+	}
+}
+
+func TestFailingHookIsReported(t *testing.T) {
+	root := t.TempDir()
+	filePath := filepath.Join(root, "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	t.Setenv("MINT_HOOKS_TIMEOUT", "1")
+	writeHook(t, root, "on-create", `echo "no webhook configured"; exit 3`)
+	writeHook(t, root, "on-close", `sleep 5`)
+
+	cmd := newCommand()
+	var out, errOut bytes.Buffer
+	cmd.Writer, cmd.ErrWriter = &out, &errOut
+	if err := cmd.Run(context.Background(), []string{"mint", "create", "Write parser"}); err != nil {
+		t.Fatalf("expected create to succeed despite the hook, got %v", err)
+	}
+	if !strings.Contains(errOut.String(), "no webhook configured\nwarning: on-create hook failed for ") ||
+		!strings.Contains(errOut.String(), "exit status 3") {
+		t.Errorf("expected the failure to be reported, got %q", errOut.String())
+	}
+
+	store, _ := LoadStore(filePath)
+	if len(store.Issues) != 1 {
+		t.Fatalf("expected the issue to be saved, got %d issues", len(store.Issues))
+	}
+	issue := store.ListIssues()[0]
+
+	errOut.Reset()
+	cmd = newCommand()
+	cmd.Writer, cmd.ErrWriter = &out, &errOut
+	if err := cmd.Run(context.Background(), []string{"mint", "close", issue.ID}); err != nil {
+		t.Fatalf("expected close to succeed despite the hook, got %v", err)
+	}
+	if !strings.Contains(errOut.String(), "on-close hook failed for "+issue.ID+": timed out after 1s") {
+		t.Errorf("expected a timeout to be reported, got %q", errOut.String())
+	}
+}
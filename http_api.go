@@ -0,0 +1,416 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxAPIBodySize bounds a request body sent to the HTTP API
+const maxAPIBodySize = 1 << 20
+
+// apiServer serves the store as a JSON API over HTTP. Requests are handled
+// one at a time against a store kept in memory, which is reloaded whenever
+// the file changes on disk, so changes made with the CLI are seen.
+type apiServer struct {
+	filePath string
+	host     string // the host the server listens on, besides localhost
+
+	mu     sync.Mutex
+	store  *Store
+	loaded os.FileInfo // the file as of the last load or save; nil if missing
+}
+
+func newAPIServer(filePath, host string) *apiServer {
+	return &apiServer{filePath: filePath, host: host}
+}
+
+// statusError is an error with the HTTP status it should be reported with
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+// apiHandler handles a request with the current store and returns the
+// status and body of the response. Handlers that change the store report
+// it, and the store is saved.
+type apiHandler func(store *Store, r *http.Request) (status int, body any, changed bool, err error)
+
+// Handler returns the API's routes
+func (s *apiServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	routes := map[string]apiHandler{
+		"GET /issues":                            apiListIssues,
+		"POST /issues":                           apiCreateIssue,
+		"GET /issues/{id}":                       apiGetIssue,
+		"PATCH /issues/{id}":                     apiUpdateIssue,
+		"DELETE /issues/{id}":                    apiDeleteIssue,
+		"GET /issues/{id}/comments":              apiListComments,
+		"POST /issues/{id}/comments":             apiAddComment,
+		"POST /issues/{id}/depends_on":           apiAddRelationship("depends_on"),
+		"DELETE /issues/{id}/depends_on/{other}": apiRemoveRelationship("depends_on"),
+		"POST /issues/{id}/blocks":               apiAddRelationship("blocks"),
+		"DELETE /issues/{id}/blocks/{other}":     apiRemoveRelationship("blocks"),
+		"GET /ready":                             apiReady,
+	}
+	for pattern, handler := range routes {
+		mux.Handle(pattern, s.serve(handler))
+	}
+	return s.checkHost(mux)
+}
+
+// checkHost rejects requests for hosts other than localhost and the one
+// the server listens on, so a web page can't reach the API by pointing its
+// own domain at this machine
+func (s *apiServer) checkHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowedHost(r.Host) {
+			writeAPIError(w, &statusError{http.StatusForbidden, fmt.Errorf("host %q is not allowed", r.Host)})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *apiServer) allowedHost(host string) bool {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") || (s.host != "" && strings.EqualFold(host, s.host)) {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// serve runs handler under the server's lock, saving the store if it
+// changed. A failed handler's changes are discarded by reloading the store.
+func (s *apiServer) serve(handler apiHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, body, err := s.handle(handler, r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeJSON(w, status, body)
+	})
+}
+
+func (s *apiServer) handle(handler apiHandler, r *http.Request) (int, any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.refresh(); err != nil {
+		return 0, nil, &statusError{http.StatusInternalServerError, err}
+	}
+	status, body, changed, err := handler(s.store, r)
+	if err != nil {
+		s.store = nil
+		return 0, nil, err
+	}
+	if changed {
+		if err := saveStore(s.store, s.filePath, os.Stderr); err != nil {
+			s.store = nil
+			var rejected *hookRejectedError
+			if !errors.As(err, &rejected) {
+				err = &statusError{http.StatusInternalServerError, err}
+			}
+			return 0, nil, err
+		}
+		s.loaded, _ = os.Stat(s.filePath)
+	}
+	return status, body, nil
+}
+
+// refresh loads the store unless the one in memory matches the file
+func (s *apiServer) refresh() error {
+	info, err := os.Stat(s.filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if s.store != nil && sameFileInfo(s.loaded, info) {
+		return nil
+	}
+	store, err := openStore(s.filePath)
+	if err != nil {
+		return err
+	}
+	s.store, s.loaded = store, info
+	return nil
+}
+
+// sameFileInfo reports whether a and b describe the same version of a
+// file. Saves replace the file, so an unchanged file is the same file with
+// the same size and modification time.
+func sameFileInfo(a, b os.FileInfo) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return os.SameFile(a, b) && a.Size() == b.Size() && a.ModTime().Equal(b.ModTime())
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(body)
+}
+
+// writeAPIError reports err as {"error": message}. Errors from the store
+// carry no status, so it's chosen from the message.
+func writeAPIError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	var statusErr *statusError
+	switch message := err.Error(); {
+	case errors.As(err, &statusErr):
+		status = statusErr.status
+	case strings.Contains(message, "not found"):
+		status = http.StatusNotFound
+	case errors.As(err, new(*hookRejectedError)),
+		strings.Contains(message, "dependency cycle"), strings.Contains(message, "is claimed by"),
+		strings.Contains(message, "depends on open issues"):
+		status = http.StatusConflict
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// decodeBody decodes a JSON request body into v, rejecting unknown fields.
+// Bodies must be sent as application/json, which a web page can't do
+// without the server allowing it.
+func decodeBody(r *http.Request, v any) error {
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		return &statusError{http.StatusUnsupportedMediaType, fmt.Errorf("request body must be application/json")}
+	}
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxAPIBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %v", err)
+	}
+	return nil
+}
+
+// pathIssue resolves the issue named by the request's {id}
+func pathIssue(store *Store, r *http.Request) (*Issue, error) {
+	id, err := store.ResolveIssueID(r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	return store.GetIssue(id)
+}
+
+func apiListIssues(store *Store, r *http.Request) (int, any, bool, error) {
+	query := r.URL.Query()
+	in := listParams{
+		Status: query.Get("status"),
+		Label:  query.Get("label"),
+		Owner:  query.Get("owner"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			return 0, nil, false, fmt.Errorf("invalid limit %q", limit)
+		}
+		in.Limit = n
+	}
+
+	// Search before applying the limit, so it counts matches
+	terms := strings.Fields(strings.ToLower(query.Get("q")))
+	limit := in.Limit
+	if len(terms) > 0 {
+		in.Limit = 0
+	}
+	issues, err := filterIssues(store, in)
+	if err != nil {
+		return 0, nil, false, err
+	}
+	if len(terms) > 0 {
+		issues = slices.DeleteFunc(issues, func(issue *Issue) bool { return !issueMatches(issue, terms) })
+		if limit > 0 && len(issues) > limit {
+			issues = issues[:limit]
+		}
+	}
+	return http.StatusOK, issuesResult(store, issues), false, nil
+}
+
+func apiCreateIssue(store *Store, r *http.Request) (int, any, bool, error) {
+	var in createParams
+	if err := decodeBody(r, &in); err != nil {
+		return 0, nil, false, err
+	}
+	issue, err := createIssue(store, in)
+	if err != nil {
+		return 0, nil, false, err
+	}
+	return http.StatusCreated, issueResult(store, issue), true, nil
+}
+
+func apiGetIssue(store *Store, r *http.Request) (int, any, bool, error) {
+	issue, err := pathIssue(store, r)
+	if err != nil {
+		return 0, nil, false, err
+	}
+	return http.StatusOK, issueResult(store, issue), false, nil
+}
+
+func apiUpdateIssue(store *Store, r *http.Request) (int, any, bool, error) {
+	issue, err := pathIssue(store, r)
+	if err != nil {
+		return 0, nil, false, err
+	}
+	var in struct {
+		updateParams
+		Status      *string `json:"status"`
+		Resolution  string  `json:"resolution"`
+		Reason      string  `json:"reason"`
+		DuplicateOf string  `json:"duplicate_of"`
+		Force       bool    `json:"force"`
+		Cascade     bool    `json:"cascade"`
+		Owner       *string `json:"owner"`
+		ExternalRef *string `json:"external_ref"`
+	}
+	if err := decodeBody(r, &in); err != nil {
+		return 0, nil, false, err
+	}
+	if (in.Resolution != "" || in.Reason != "" || in.DuplicateOf != "" || in.Force || in.Cascade) && (in.Status == nil || *in.Status != "closed") {
+		return 0, nil, false, fmt.Errorf("resolution, reason, duplicate_of, force, and cascade are only allowed when closing")
+	}
+	if in.Resolution == "" {
+		in.Resolution = resolutionDone
+	}
+
+	if err := updateIssue(store, issue, in.updateParams); err != nil {
+		return 0, nil, false, err
+	}
+	if in.Owner != nil && *in.Owner != issue.Owner {
+		issue.Owner = strings.TrimSpace(*in.Owner)
+		store.touch(issue)
+	}
+	if in.ExternalRef != nil && *in.ExternalRef != issue.ExternalRef {
+		issue.ExternalRef = strings.TrimSpace(*in.ExternalRef)
+		store.touch(issue)
+	}
+	if in.Status != nil && *in.Status != issue.Status {
+		switch *in.Status {
+		case "closed":
+			// Closing reports what it unblocked
+			blocked := store.blockedDependents(issue.ID)
+			closed, err := store.CloseIssues(issue.ID, in.Resolution, in.Reason, in.DuplicateOf, in.Cascade, in.Force)
+			if err != nil {
+				return 0, nil, false, err
+			}
+			return http.StatusOK, closeResult(store, closed, store.nowReady(blocked)), true, nil
+		case "open":
+			if err := store.ReopenIssue(issue.ID); err != nil {
+				return 0, nil, false, err
+			}
+		default:
+			return 0, nil, false, fmt.Errorf("invalid status %q (must be open or closed)", *in.Status)
+		}
+	}
+	return http.StatusOK, issueResult(store, issue), true, nil
+}
+
+func apiDeleteIssue(store *Store, r *http.Request) (int, any, bool, error) {
+	issue, err := pathIssue(store, r)
+	if err != nil {
+		return 0, nil, false, err
+	}
+	blocked := store.blockedDependents(issue.ID)
+	if err := store.DeleteIssue(issue.ID); err != nil {
+		return 0, nil, false, err
+	}
+	return http.StatusOK, closeResult(store, nil, store.nowReady(blocked)), true, nil
+}
+
+func apiListComments(store *Store, r *http.Request) (int, any, bool, error) {
+	issue, err := pathIssue(store, r)
+	if err != nil {
+		return 0, nil, false, err
+	}
+	return http.StatusOK, map[string][]string{"comments": toAPIIssue(store, issue).Comments}, false, nil
+}
+
+func apiAddComment(store *Store, r *http.Request) (int, any, bool, error) {
+	issue, err := pathIssue(store, r)
+	if err != nil {
+		return 0, nil, false, err
+	}
+	var in struct {
+		Comment string `json:"comment"`
+	}
+	if err := decodeBody(r, &in); err != nil {
+		return 0, nil, false, err
+	}
+	if strings.TrimSpace(in.Comment) == "" {
+		return 0, nil, false, fmt.Errorf("comment is required")
+	}
+	if err := store.AddComment(issue.ID, in.Comment); err != nil {
+		return 0, nil, false, err
+	}
+	return http.StatusCreated, issueResult(store, issue), true, nil
+}
+
+// apiAddRelationship returns a handler adding the issue in the body to the
+// path issue's depends_on or blocks
+func apiAddRelationship(field string) apiHandler {
+	return func(store *Store, r *http.Request) (int, any, bool, error) {
+		issue, err := pathIssue(store, r)
+		if err != nil {
+			return 0, nil, false, err
+		}
+		var in struct {
+			ID string `json:"id"`
+		}
+		if err := decodeBody(r, &in); err != nil {
+			return 0, nil, false, err
+		}
+		if in.ID == "" {
+			return 0, nil, false, fmt.Errorf("id is required")
+		}
+		update := updateParams{AddDependsOn: []string{in.ID}}
+		if field == "blocks" {
+			update = updateParams{AddBlocks: []string{in.ID}}
+		}
+		if err := updateIssue(store, issue, update); err != nil {
+			return 0, nil, false, err
+		}
+		return http.StatusOK, issueResult(store, issue), true, nil
+	}
+}
+
+// apiRemoveRelationship returns a handler removing {other} from the path
+// issue's depends_on or blocks
+func apiRemoveRelationship(field string) apiHandler {
+	return func(store *Store, r *http.Request) (int, any, bool, error) {
+		issue, err := pathIssue(store, r)
+		if err != nil {
+			return 0, nil, false, err
+		}
+		update := updateParams{RemoveDependsOn: []string{r.PathValue("other")}}
+		if field == "blocks" {
+			update = updateParams{RemoveBlocks: []string{r.PathValue("other")}}
+		}
+		if err := updateIssue(store, issue, update); err != nil {
+			return 0, nil, false, err
+		}
+		return http.StatusOK, issueResult(store, issue), true, nil
+	}
+}
+
+func apiReady(store *Store, _ *http.Request) (int, any, bool, error) {
+	return http.StatusOK, issuesResult(store, readyQueue(store)), false, nil
+}
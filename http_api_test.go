@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// apiTestClient sends requests to a test server for the API
+type apiTestClient struct {
+	t      *testing.T
+	server *httptest.Server
+}
+
+func newAPITestClient(t *testing.T, filePath string) *apiTestClient {
+	t.Helper()
+	server := httptest.NewServer(newAPIServer(filePath, "").Handler())
+	t.Cleanup(server.Close)
+	return &apiTestClient{t: t, server: server}
+}
+
+// do sends body as JSON, checks the response status, and decodes the
+// response into out unless it's nil
+func (c *apiTestClient) do(method, path string, body any, wantStatus int, out any) {
+	c.t.Helper()
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, c.server.URL+path, bytes.NewReader(data))
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.server.Client().Do(req)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var raw json.RawMessage
+	_ = json.NewDecoder(resp.Body).Decode(&raw)
+	if resp.StatusCode != wantStatus {
+		c.t.Fatalf("%s %s: expected status %d, got %d: %s", method, path, wantStatus, resp.StatusCode, raw)
+	}
+	if out != nil {
+		if err := json.Unmarshal(raw, out); err != nil {
+			c.t.Fatalf("%s %s: %v: %s", method, path, err, raw)
+		}
+	}
+}
+
+func TestAPIIssues(t *testing.T) {
+	filePath := newMCPTestStore(t)
+	c := newAPITestClient(t, filePath)
+
+	var parser, docs apiIssueResult
+	c.do("POST", "/issues", map[string]any{"title": "Write parser", "description": "Handle nesting", "labels": []string{"core"}}, http.StatusCreated, &parser)
+	c.do("POST", "/issues", map[string]any{"title": "Write docs", "depends_on": []string{parser.Issue.ID}}, http.StatusCreated, &docs)
+	if docs.Issue.Ready || len(docs.Issue.DependsOn) != 1 {
+		t.Errorf("expected docs to depend on the parser, got %+v", docs.Issue)
+	}
+
+	var ready apiIssuesResult
+	c.do("GET", "/ready", nil, http.StatusOK, &ready)
+	if len(ready.Issues) != 1 || ready.Issues[0].ID != parser.Issue.ID {
+		t.Errorf("expected only the parser to be ready, got %+v", ready.Issues)
+	}
+
+	var listed apiIssuesResult
+	c.do("GET", "/issues?status=blocked", nil, http.StatusOK, &listed)
+	if len(listed.Issues) != 1 || listed.Issues[0].ID != docs.Issue.ID {
+		t.Errorf("expected docs to be blocked, got %+v", listed.Issues)
+	}
+	c.do("GET", "/issues?q=NESTING", nil, http.StatusOK, &listed)
+	if len(listed.Issues) != 1 || listed.Issues[0].ID != parser.Issue.ID {
+		t.Errorf("expected search to find the parser, got %+v", listed.Issues)
+	}
+
+	// Partial IDs resolve like they do on the command line
+	var shown apiIssueResult
+	c.do("GET", "/issues/"+parser.Issue.ID[:len(parser.Issue.ID)-1], nil, http.StatusOK, &shown)
+	if shown.Issue.Title != "Write parser" {
+		t.Errorf("expected the parser, got %+v", shown.Issue)
+	}
+
+	var updated apiIssueResult
+	c.do("PATCH", "/issues/"+parser.Issue.ID, map[string]any{"title": "Write the parser", "owner": "agent-1", "add_labels": []string{"v2"}}, http.StatusOK, &updated)
+	if updated.Issue.Title != "Write the parser" || updated.Issue.Owner != "agent-1" || len(updated.Issue.Labels) != 2 {
+		t.Errorf("unexpected update result: %+v", updated.Issue)
+	}
+	var closed apiCloseResult
+	c.do("PATCH", "/issues/"+parser.Issue.ID, map[string]any{"status": "closed", "reason": "done"}, http.StatusOK, &closed)
+	if closed.Issue.Status != "closed" || closed.Issue.Resolution != "done" || closed.Issue.Reason != "done" {
+		t.Errorf("expected the parser to be closed as done, got %+v", closed.Issue)
+	}
+	if len(closed.NowReady) != 1 || closed.NowReady[0].ID != docs.Issue.ID || !closed.NowReady[0].Ready {
+		t.Errorf("expected docs to be ready once the parser closed, got %+v", closed.NowReady)
+	}
+
+	var deleted apiCloseResult
+	c.do("DELETE", "/issues/"+docs.Issue.ID, nil, http.StatusOK, &deleted)
+	if deleted.Issue != nil || deleted.NowReady == nil || len(deleted.NowReady) != 0 {
+		t.Errorf("expected nothing to be unblocked by deleting docs, got %+v", deleted)
+	}
+	c.do("GET", "/issues/"+docs.Issue.ID, nil, http.StatusNotFound, nil)
+
+	store, _ := LoadStore(filePath)
+	if len(store.Issues) != 1 || store.Issues[parser.Issue.ID].Status != "closed" {
+		t.Errorf("expected changes to be saved, got %+v", store.Issues)
+	}
+}
+
+func TestAPICommentsAndRelationships(t *testing.T) {
+	c := newAPITestClient(t, newMCPTestStore(t))
+
+	var a, b apiIssueResult
+	c.do("POST", "/issues", map[string]any{"title": "A"}, http.StatusCreated, &a)
+	c.do("POST", "/issues", map[string]any{"title": "B"}, http.StatusCreated, &b)
+
+	c.do("POST", "/issues/"+a.Issue.ID+"/comments", map[string]any{"comment": "Looks good"}, http.StatusCreated, nil)
+	var comments struct {
+		Comments []string `json:"comments"`
+	}
+	c.do("GET", "/issues/"+a.Issue.ID+"/comments", nil, http.StatusOK, &comments)
+	if len(comments.Comments) != 1 || comments.Comments[0] != "Looks good" {
+		t.Errorf("unexpected comments: %v", comments.Comments)
+	}
+
+	var result apiIssueResult
+	c.do("POST", "/issues/"+a.Issue.ID+"/blocks", map[string]any{"id": b.Issue.ID}, http.StatusOK, &result)
+	if len(result.Issue.Blocks) != 1 {
+		t.Errorf("expected A to block B, got %+v", result.Issue)
+	}
+	// Adding it again is a no-op
+	c.do("POST", "/issues/"+a.Issue.ID+"/blocks", map[string]any{"id": b.Issue.ID}, http.StatusOK, &result)
+	if len(result.Issue.Blocks) != 1 {
+		t.Errorf("expected no duplicate, got %+v", result.Issue)
+	}
+
+	c.do("POST", "/issues/"+a.Issue.ID+"/depends_on", map[string]any{"id": b.Issue.ID}, http.StatusConflict, nil)
+	c.do("GET", "/issues/"+a.Issue.ID, nil, http.StatusOK, &result)
+	if len(result.Issue.DependsOn) != 0 {
+		t.Errorf("expected the rejected dependency to be discarded, got %+v", result.Issue)
+	}
+
+	c.do("DELETE", "/issues/"+b.Issue.ID+"/depends_on/"+a.Issue.ID, nil, http.StatusOK, &result)
+	if len(result.Issue.DependsOn) != 0 {
+		t.Errorf("expected B to no longer depend on A, got %+v", result.Issue)
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	filePath := newMCPTestStore(t)
+	c := newAPITestClient(t, filePath)
+
+	var created apiIssueResult
+	c.do("POST", "/issues", map[string]any{"title": "A"}, http.StatusCreated, &created)
+
+	var failure struct {
+		Error string `json:"error"`
+	}
+	c.do("GET", "/issues/nope", nil, http.StatusNotFound, &failure)
+	if failure.Error == "" {
+		t.Errorf("expected an error message")
+	}
+	c.do("POST", "/issues", map[string]any{"titel": "typo"}, http.StatusBadRequest, nil)
+	c.do("GET", "/issues?status=sideways", nil, http.StatusBadRequest, nil)
+	c.do("PATCH", "/issues/"+created.Issue.ID, map[string]any{"status": "done"}, http.StatusBadRequest, nil)
+	c.do("PATCH", "/issues/"+created.Issue.ID, map[string]any{"reason": "why"}, http.StatusBadRequest, nil)
+	c.do("PATCH", "/issues/"+created.Issue.ID, map[string]any{"status": "closed", "resolution": "duplicate"}, http.StatusBadRequest, nil)
+
+	writeHook(t, filepath.Dir(filePath), "pre-close", "exit 1")
+	c.do("PATCH", "/issues/"+created.Issue.ID, map[string]any{"status": "closed"}, http.StatusConflict, nil)
+	var shown apiIssueResult
+	c.do("GET", "/issues/"+created.Issue.ID, nil, http.StatusOK, &shown)
+	if shown.Issue.Status != "open" {
+		t.Errorf("expected the rejected close to be discarded, got %+v", shown.Issue)
+	}
+}
+
+func TestAPICloseDependencies(t *testing.T) {
+	filePath := newMCPTestStore(t)
+	c := newAPITestClient(t, filePath)
+
+	var design, build apiIssueResult
+	c.do("POST", "/issues", map[string]any{"title": "Design"}, http.StatusCreated, &design)
+	c.do("POST", "/issues", map[string]any{"title": "Build", "depends_on": []string{design.Issue.ID}}, http.StatusCreated, &build)
+
+	c.do("PATCH", "/issues/"+build.Issue.ID, map[string]any{"status": "closed"}, http.StatusConflict, nil)
+	c.do("PATCH", "/issues/"+build.Issue.ID, map[string]any{"cascade": true}, http.StatusBadRequest, nil)
+
+	var closed apiCloseResult
+	c.do("PATCH", "/issues/"+design.Issue.ID, map[string]any{"status": "closed", "cascade": true}, http.StatusOK, &closed)
+	if len(closed.AlsoClosed) != 1 || closed.AlsoClosed[0].ID != build.Issue.ID || closed.AlsoClosed[0].Status != "closed" {
+		t.Errorf("expected build to be closed along with design, got %+v", closed.AlsoClosed)
+	}
+}
+
+func TestAPIReloadsChangedFile(t *testing.T) {
+	filePath := newMCPTestStore(t)
+	c := newAPITestClient(t, filePath)
+
+	var listed apiIssuesResult
+	c.do("GET", "/issues", nil, http.StatusOK, &listed)
+	if len(listed.Issues) != 0 {
+		t.Fatalf("expected no issues, got %+v", listed.Issues)
+	}
+
+	// Another process, like the CLI, changes the file
+	store, _ := LoadStore(filePath)
+	if _, err := store.AddIssue("Added elsewhere"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	c.do("GET", "/issues", nil, http.StatusOK, &listed)
+	if len(listed.Issues) != 1 || listed.Issues[0].Title != "Added elsewhere" {
+		t.Errorf("expected the server to reload, got %+v", listed.Issues)
+	}
+
+	// Writes build on the reloaded store rather than overwriting it
+	c.do("POST", "/issues", map[string]any{"title": "Added by API"}, http.StatusCreated, nil)
+	store, _ = LoadStore(filePath)
+	if len(store.Issues) != 2 {
+		t.Errorf("expected both issues to be saved, got %d", len(store.Issues))
+	}
+}
+
+func TestAPIRejectsCrossSiteRequests(t *testing.T) {
+	filePath := newMCPTestStore(t)
+	c := newAPITestClient(t, filePath)
+
+	// A form or fetch from a web page can only send simple content types
+	resp, err := c.server.Client().Post(c.server.URL+"/issues", "text/plain", strings.NewReader(`{"title": "From a web page"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("expected status %d for text/plain, got %d", http.StatusUnsupportedMediaType, resp.StatusCode)
+	}
+
+	// A domain rebound to this machine still sends its own name as the host
+	req, _ := http.NewRequest("GET", c.server.URL+"/issues", nil)
+	req.Host = "attacker.example:7777"
+	resp, err = c.server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected status %d for another host, got %d", http.StatusForbidden, resp.StatusCode)
+	}
+
+	store, _ := LoadStore(filePath)
+	if len(store.Issues) != 0 {
+		t.Errorf("expected nothing to be created, got %d issues", len(store.Issues))
+	}
+
+	server := newAPIServer(filePath, "devbox.local")
+	for host, want := range map[string]bool{
+		"localhost:7777":    true,
+		"127.0.0.1:7777":    true,
+		"[::1]:7777":        true,
+		"devbox.local:7777": true,
+		"evil.example":      false,
+		"10.0.0.5:7777":     false,
+	} {
+		if got := server.allowedHost(host); got != want {
+			t.Errorf("allowedHost(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
@@ -17,6 +17,11 @@ const (
 // to maintain 0.1% collision probability using birthday paradox formula:
 // L = max(minIDLength, ceil(log_base(n² / (2 × ln(1/(1-P))))))
 func CalculateIDLength(issueCount int) int {
+	return calculateIDLength(issueCount, alphabetSize)
+}
+
+// calculateIDLength is CalculateIDLength for an alphabet of the given size
+func calculateIDLength(issueCount, size int) int {
 	if issueCount <= 0 {
 		return minIDLength
 	}
@@ -29,7 +34,7 @@ func CalculateIDLength(issueCount int) int {
 	requiredCapacity := (n * n) / denominator
 
 	// Calculate required length: log_base(requiredCapacity)
-	length := math.Log(requiredCapacity) / math.Log(alphabetSize)
+	length := math.Log(requiredCapacity) / math.Log(float64(size))
 
 	// Round up and apply minimum
 	calculatedLength := int(math.Ceil(length))
@@ -43,7 +48,12 @@ func CalculateIDLength(issueCount int) int {
 // Example: GenerateID("mint", 7) -> "mint-xgmx5l6"
 // Example: GenerateID("", 7) -> "xgmx5l6"
 func GenerateID(prefix string, length int) string {
-	id, err := gonanoid.Generate(customAlphabet, length)
+	return generateID(prefix, customAlphabet, length)
+}
+
+// generateID is GenerateID with a custom alphabet
+func generateID(prefix, alphabet string, length int) string {
+	id, err := gonanoid.Generate(alphabet, length)
 	if err != nil {
 		// Fallback to default if generation fails (should be rare)
 		panic(err)
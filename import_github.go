@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// githubIssue is a single issue as emitted by `gh issue list --json ...`
+type githubIssue struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	State     string    `json:"state"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Labels    []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Comments []struct {
+		Author struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		Body string `json:"body"`
+	} `json:"comments"`
+}
+
+// blockedByPattern matches references like "Blocked by #12" or
+// "depends on #3, #4 and #5" in an issue body
+var blockedByPattern = regexp.MustCompile(`(?i)(?:blocked by|depends on):?((?:\s*(?:,|and)?\s*#\d+)+)`)
+
+var issueNumberPattern = regexp.MustCompile(`#(\d+)`)
+
+// githubRef builds the external reference for a GitHub issue, preferring
+// the issue URL so issues from different repositories don't collide
+func githubRef(url string, number int) string {
+	if url != "" {
+		return url
+	}
+	return "github#" + strconv.Itoa(number)
+}
+
+// githubSiblingRef builds the external reference for another issue in the
+// same repository as the issue at url
+func githubSiblingRef(url string, number int) string {
+	if i := strings.LastIndex(url, "/issues/"); i >= 0 {
+		return url[:i] + "/issues/" + strconv.Itoa(number)
+	}
+	return githubRef("", number)
+}
+
+// ImportGitHub imports issues from a GitHub JSON export into the store
+// Issues already imported (matched by external reference) are updated in
+// place, so importing the same file twice changes nothing
+func ImportGitHub(store *Store, data []byte) (*importResult, error) {
+	var ghIssues []githubIssue
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		var single githubIssue
+		if err := json.Unmarshal(trimmed, &single); err != nil {
+			return nil, fmt.Errorf("invalid GitHub issue JSON: %w", err)
+		}
+		ghIssues = append(ghIssues, single)
+	} else if err := json.Unmarshal(trimmed, &ghIssues); err != nil {
+		return nil, fmt.Errorf("invalid GitHub issue JSON: %w", err)
+	}
+
+	result := &importResult{}
+	imported := make([]*Issue, len(ghIssues))
+	changed := make(map[*Issue]bool)
+	created := make(map[*Issue]bool)
+
+	for i, gh := range ghIssues {
+		if gh.Number == 0 || gh.Title == "" {
+			return nil, fmt.Errorf("GitHub issue at index %d is missing a number or title", i)
+		}
+
+		ref := githubRef(gh.URL, gh.Number)
+		issue := store.FindIssueByExternalRef(ref)
+		if issue == nil {
+			var err error
+			issue, err = store.AddIssue(gh.Title)
+			if err != nil {
+				return nil, err
+			}
+			issue.ExternalRef = ref
+			if !gh.CreatedAt.IsZero() {
+				issue.CreatedAt = gh.CreatedAt
+			}
+			created[issue] = true
+		}
+		imported[i] = issue
+
+		labels := make([]string, 0, len(gh.Labels))
+		for _, label := range gh.Labels {
+			labels = append(labels, label.Name)
+		}
+		comments := make([]string, 0, len(gh.Comments)+1)
+		if body := strings.TrimSpace(gh.Body); body != "" {
+			comments = append(comments, body)
+		}
+		for _, comment := range gh.Comments {
+			author := ""
+			if comment.Author.Login != "" {
+				author = "@" + comment.Author.Login
+			}
+			comments = append(comments, attributedComment(author, comment.Body))
+		}
+
+		updated, err := updateImportedIssue(store, issue, gh.Title, labels, comments, strings.EqualFold(gh.State, "closed"))
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			changed[issue] = true
+		}
+	}
+
+	// Link "blocked by #N" references once every issue in the file exists
+	for i, gh := range ghIssues {
+		issue := imported[i]
+		for _, match := range blockedByPattern.FindAllStringSubmatch(gh.Body, -1) {
+			for _, num := range issueNumberPattern.FindAllStringSubmatch(match[1], -1) {
+				number, _ := strconv.Atoi(num[1])
+				blocker := store.FindIssueByExternalRef(githubSiblingRef(gh.URL, number))
+				if blocker == nil || blocker == issue || slices.Contains(issue.DependsOn, blocker.ID) {
+					continue
+				}
+				if err := store.AddDependency(issue.ID, blocker.ID); err != nil {
+					return nil, err
+				}
+				changed[issue] = true
+				changed[blocker] = true
+			}
+		}
+	}
+
+	for i, gh := range ghIssues {
+		issue := imported[i]
+		switch {
+		case created[issue]:
+			if !gh.UpdatedAt.IsZero() {
+				issue.UpdatedAt = gh.UpdatedAt
+			}
+			result.Created = append(result.Created, issue)
+		case changed[issue]:
+			result.Updated = append(result.Updated, issue)
+		default:
+			result.Unchanged++
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"testing"
+)
+
+const githubExport = `[
+  {
+    "number": 1,
+    "title": "Set up CI",
+    "body": "Use GitHub Actions",
+    "state": "CLOSED",
+    "url": "https://github.com/acme/widgets/issues/1",
+    "createdAt": "2024-01-02T10:00:00Z",
+    "updatedAt": "2024-01-05T10:00:00Z",
+    "labels": [{"name": "infra"}],
+    "comments": [{"author": {"login": "octocat"}, "body": "Done in #4"}]
+  },
+  {
+    "number": 2,
+    "title": "Write tests",
+    "body": "Blocked by #1 and #3",
+    "state": "OPEN",
+    "url": "https://github.com/acme/widgets/issues/2",
+    "labels": [{"name": "testing"}, {"name": "good first issue"}],
+    "comments": []
+  },
+  {
+    "number": 3,
+    "title": "Pick a test framework",
+    "body": "",
+    "state": "OPEN",
+    "url": "https://github.com/acme/widgets/issues/3",
+    "labels": [],
+    "comments": []
+  }
+]`
+
+func TestImportGitHub(t *testing.T) {
+	store := NewStore()
+
+	result, err := ImportGitHub(store, []byte(githubExport))
+	if err != nil {
+		t.Fatalf("ImportGitHub() failed: %v", err)
+	}
+
+	if len(result.Created) != 3 {
+		t.Fatalf("expected 3 created issues, got %d", len(result.Created))
+	}
+
+	ci := store.FindIssueByExternalRef("https://github.com/acme/widgets/issues/1")
+	tests := store.FindIssueByExternalRef("https://github.com/acme/widgets/issues/2")
+	framework := store.FindIssueByExternalRef("https://github.com/acme/widgets/issues/3")
+	if ci == nil || tests == nil || framework == nil {
+		t.Fatal("expected every issue to be findable by its external reference")
+	}
+
+	if ci.Status != "closed" {
+		t.Errorf("expected closed issue, got '%s'", ci.Status)
+	}
+	if ci.CreatedAt.Year() != 2024 || ci.UpdatedAt.Day() != 5 {
+		t.Errorf("expected timestamps from GitHub, got %v / %v", ci.CreatedAt, ci.UpdatedAt)
+	}
+	if len(ci.Labels) != 1 || ci.Labels[0] != "infra" {
+		t.Errorf("expected labels [infra], got %v", ci.Labels)
+	}
+	if len(ci.Comments) != 2 || ci.Comments[0] != "Use GitHub Actions" || ci.Comments[1] != "@octocat: Done in #4" {
+		t.Errorf("unexpected comments: %v", ci.Comments)
+	}
+
+	if len(tests.DependsOn) != 2 || tests.DependsOn[0] != ci.ID || tests.DependsOn[1] != framework.ID {
+		t.Errorf("expected tests to depend on [%s %s], got %v", ci.ID, framework.ID, tests.DependsOn)
+	}
+	if len(framework.Blocks) != 1 || framework.Blocks[0] != tests.ID {
+		t.Errorf("expected framework to block %s, got %v", tests.ID, framework.Blocks)
+	}
+	if len(framework.Comments) != 0 {
+		t.Errorf("expected no comments for empty body, got %v", framework.Comments)
+	}
+}
+
+func TestImportGitHub_Idempotent(t *testing.T) {
+	store := NewStore()
+
+	if _, err := ImportGitHub(store, []byte(githubExport)); err != nil {
+		t.Fatalf("first ImportGitHub() failed: %v", err)
+	}
+
+	result, err := ImportGitHub(store, []byte(githubExport))
+	if err != nil {
+		t.Fatalf("second ImportGitHub() failed: %v", err)
+	}
+
+	if len(store.Issues) != 3 {
+		t.Errorf("expected 3 issues after re-import, got %d", len(store.Issues))
+	}
+	if len(result.Created) != 0 || len(result.Updated) != 0 || result.Unchanged != 3 {
+		t.Errorf("expected everything unchanged, got %d created, %d updated, %d unchanged",
+			len(result.Created), len(result.Updated), result.Unchanged)
+	}
+
+	tests := store.FindIssueByExternalRef("https://github.com/acme/widgets/issues/2")
+	if len(tests.DependsOn) != 2 {
+		t.Errorf("expected dependencies not to be duplicated, got %v", tests.DependsOn)
+	}
+}
+
+func TestImportGitHub_UpdatesChangedIssues(t *testing.T) {
+	store := NewStore()
+
+	if _, err := ImportGitHub(store, []byte(githubExport)); err != nil {
+		t.Fatalf("first ImportGitHub() failed: %v", err)
+	}
+
+	updated := `[{"number": 3, "title": "Pick a test framework (go test)", "state": "CLOSED",
+		"url": "https://github.com/acme/widgets/issues/3",
+		"comments": [{"author": {"login": "hubot"}, "body": "Going with go test"}]}]`
+
+	result, err := ImportGitHub(store, []byte(updated))
+	if err != nil {
+		t.Fatalf("second ImportGitHub() failed: %v", err)
+	}
+	if len(result.Updated) != 1 {
+		t.Fatalf("expected 1 updated issue, got %d", len(result.Updated))
+	}
+
+	framework := result.Updated[0]
+	if framework.Title != "Pick a test framework (go test)" {
+		t.Errorf("expected title to be updated, got '%s'", framework.Title)
+	}
+	if framework.Status != "closed" {
+		t.Errorf("expected status 'closed', got '%s'", framework.Status)
+	}
+	if len(framework.Comments) != 1 || framework.Comments[0] != "@hubot: Going with go test" {
+		t.Errorf("expected new comment to be appended, got %v", framework.Comments)
+	}
+}
+
+func TestImportGitHub_SingleObjectWithoutURL(t *testing.T) {
+	store := NewStore()
+
+	result, err := ImportGitHub(store, []byte(`{"number": 7, "title": "Lonely", "state": "OPEN"}`))
+	if err != nil {
+		t.Fatalf("ImportGitHub() failed: %v", err)
+	}
+	if len(result.Created) != 1 {
+		t.Fatalf("expected 1 created issue, got %d", len(result.Created))
+	}
+	if result.Created[0].ExternalRef != "github#7" {
+		t.Errorf("expected external ref 'github#7', got '%s'", result.Created[0].ExternalRef)
+	}
+}
+
+func TestImportGitHub_InvalidJSON(t *testing.T) {
+	store := NewStore()
+
+	if _, err := ImportGitHub(store, []byte(`not json`)); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+	if _, err := ImportGitHub(store, []byte(`[{"title": "No number"}]`)); err == nil {
+		t.Error("expected error for issue without a number")
+	}
+}
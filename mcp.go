@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// mcpProtocolVersions are the MCP versions the server speaks, newest first
+var mcpProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcInternalError  = -32603
+)
+
+// maxMCPMessageSize bounds a single message read from the client
+const maxMCPMessageSize = 16 << 20
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// mcpServer answers MCP requests over newline-delimited JSON-RPC, the stdio
+// transport. Requests are handled one at a time, and each loads the store
+// from disk so changes made with the CLI in the meantime are seen.
+type mcpServer struct {
+	filePath string
+}
+
+func newMCPServer(filePath string) *mcpServer {
+	return &mcpServer{filePath: filePath}
+}
+
+// Serve reads requests from r and writes responses to w until r is closed
+// or ctx is cancelled
+func (s *mcpServer) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMCPMessageSize)
+	encoder := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		response := s.handleMessage(ctx, []byte(line))
+		if response == nil {
+			continue
+		}
+		if err := encoder.Encode(response); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// handleMessage returns the response to one message, or nil for a
+// notification
+func (s *mcpServer) handleMessage(ctx context.Context, data []byte) *rpcResponse {
+	var req rpcRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return &rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: rpcParseError, Message: "parse error: " + err.Error()}}
+	}
+	isNotification := len(req.ID) == 0
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		if isNotification {
+			return nil
+		}
+		return &rpcResponse{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{Code: rpcInvalidRequest, Message: "invalid request"}}
+	}
+
+	result, err := s.dispatch(ctx, req.Method, req.Params)
+	if isNotification {
+		return nil
+	}
+	response := &rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: result}
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &rpcError{Code: rpcInternalError, Message: err.Error()}
+		}
+		response.Result = nil
+		response.Error = rpcErr
+	}
+	return response
+}
+
+func (s *mcpServer) dispatch(_ context.Context, method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "initialize":
+		var p struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		if err := decodeParams(params, &p, false); err != nil {
+			return nil, err
+		}
+		// Answer with the client's version when we speak it
+		protocolVersion := mcpProtocolVersions[0]
+		if slices.Contains(mcpProtocolVersions, p.ProtocolVersion) {
+			protocolVersion = p.ProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": protocolVersion,
+			"capabilities": map[string]any{
+				"tools":     map[string]any{},
+				"resources": map[string]any{},
+			},
+			"serverInfo": map[string]any{
+				"name":    "mint",
+				"version": version,
+			},
+			"instructions": "Track work as issues. Use next to find the oldest ready issue, claim it, " +
+				"and close it when done. Issues depending on open issues aren't ready.",
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+	case "tools/list":
+		tools := make([]map[string]any, 0, len(mcpTools))
+		for _, tool := range mcpTools {
+			tools = append(tools, map[string]any{
+				"name":         tool.Name,
+				"description":  tool.Description,
+				"inputSchema":  tool.InputSchema,
+				"outputSchema": tool.OutputSchema,
+			})
+		}
+		return map[string]any{"tools": tools}, nil
+	case "tools/call":
+		var p struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := decodeParams(params, &p, false); err != nil {
+			return nil, err
+		}
+		return s.callTool(p.Name, p.Arguments)
+	case "resources/list":
+		return s.listResources()
+	case "resources/templates/list":
+		return map[string]any{"resourceTemplates": []map[string]any{{
+			"uriTemplate": mcpIssueURIPrefix + "{id}",
+			"name":        "issue",
+			"description": "An issue with its relationships and comments",
+			"mimeType":    "application/json",
+		}}}, nil
+	case "resources/read":
+		var p struct {
+			URI string `json:"uri"`
+		}
+		if err := decodeParams(params, &p, false); err != nil {
+			return nil, err
+		}
+		return s.readResource(p.URI)
+	default:
+		return nil, &rpcError{Code: rpcMethodNotFound, Message: "method not found: " + method}
+	}
+}
+
+// callTool runs a tool against a freshly loaded store, saving it only if
+// the tool changed something and succeeded. Tool failures are reported in
+// the result, as MCP expects, so the model can see them.
+func (s *mcpServer) callTool(name string, args json.RawMessage) (any, error) {
+	tool := findMCPTool(name)
+	if tool == nil {
+		return nil, &rpcError{Code: rpcInvalidParams, Message: "unknown tool: " + name}
+	}
+
+	result, err := s.runTool(tool, args)
+	if err != nil {
+		return map[string]any{
+			"content": []map[string]any{{"type": "text", "text": err.Error()}},
+			"isError": true,
+		}, nil
+	}
+	text, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"content":           []map[string]any{{"type": "text", "text": string(text)}},
+		"structuredContent": result,
+	}, nil
+}
+
+func (s *mcpServer) runTool(tool *mcpTool, args json.RawMessage) (any, error) {
+	store, err := openStore(s.filePath)
+	if err != nil {
+		return nil, err
+	}
+	result, changed, err := tool.Handler(store, args)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		// Hook output goes to stderr, since stdout carries the protocol
+		if err := saveStore(store, s.filePath, os.Stderr); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// decodeParams decodes JSON-RPC params or tool arguments into v. Missing
+// params decode as an empty object. Tool arguments are strict, so typos in
+// argument names are reported rather than ignored.
+func decodeParams(params json.RawMessage, v any, strict bool) error {
+	if len(params) == 0 || string(params) == "null" {
+		params = json.RawMessage("{}")
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(params)))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(v); err != nil {
+		return &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
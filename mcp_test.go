@@ -0,0 +1,335 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mcpTestClient drives an mcpServer over in-process pipes
+type mcpTestClient struct {
+	t      *testing.T
+	in     *io.PipeWriter
+	out    *bufio.Scanner
+	nextID int
+	done   chan error
+}
+
+func newMCPTestClient(t *testing.T, filePath string) *mcpTestClient {
+	t.Helper()
+	clientOut, serverIn := io.Pipe()
+	serverOut, clientIn := io.Pipe()
+
+	c := &mcpTestClient{t: t, in: serverIn, out: bufio.NewScanner(serverOut), done: make(chan error, 1)}
+	go func() {
+		err := newMCPServer(filePath).Serve(context.Background(), clientOut, clientIn)
+		_ = clientIn.Close()
+		c.done <- err
+	}()
+	t.Cleanup(func() {
+		_ = serverIn.Close()
+		if err := <-c.done; err != nil {
+			t.Errorf("Serve() failed: %v", err)
+		}
+	})
+
+	c.call("initialize", map[string]any{"protocolVersion": "2025-06-18", "capabilities": map[string]any{}})
+	c.notify("notifications/initialized")
+	return c
+}
+
+func (c *mcpTestClient) send(message map[string]any) {
+	c.t.Helper()
+	data, _ := json.Marshal(message)
+	if _, err := fmt.Fprintf(c.in, "%s\n", data); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *mcpTestClient) notify(method string) {
+	c.send(map[string]any{"jsonrpc": "2.0", "method": method})
+}
+
+// call sends a request and returns its response
+func (c *mcpTestClient) call(method string, params any) rpcTestResponse {
+	c.t.Helper()
+	c.nextID++
+	c.send(map[string]any{"jsonrpc": "2.0", "id": c.nextID, "method": method, "params": params})
+	return c.read()
+}
+
+func (c *mcpTestClient) read() rpcTestResponse {
+	c.t.Helper()
+	if !c.out.Scan() {
+		c.t.Fatalf("no response: %v", c.out.Err())
+	}
+	var response rpcTestResponse
+	if err := json.Unmarshal(c.out.Bytes(), &response); err != nil {
+		c.t.Fatalf("invalid response %s: %v", c.out.Text(), err)
+	}
+	return response
+}
+
+// tool calls a tool and decodes its structured result into v, failing the
+// test if the tool reported an error
+func (c *mcpTestClient) tool(name string, args map[string]any, v any) {
+	c.t.Helper()
+	result := c.toolResult(name, args)
+	if result.IsError {
+		c.t.Fatalf("%s failed: %s", name, result.Content[0].Text)
+	}
+	if err := json.Unmarshal(result.StructuredContent, v); err != nil {
+		c.t.Fatalf("invalid %s result: %v", name, err)
+	}
+}
+
+func (c *mcpTestClient) toolResult(name string, args map[string]any) mcpTestToolResult {
+	c.t.Helper()
+	response := c.call("tools/call", map[string]any{"name": name, "arguments": args})
+	if response.Error != nil {
+		c.t.Fatalf("%s: %s", name, response.Error.Message)
+	}
+	var result mcpTestToolResult
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		c.t.Fatal(err)
+	}
+	return result
+}
+
+type rpcTestResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type mcpTestToolResult struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent"`
+	IsError           bool            `json:"isError"`
+}
+
+func newMCPTestStore(t *testing.T) string {
+	t.Helper()
+	filePath := filepath.Join(t.TempDir(), "mint-issues.yaml")
+	t.Setenv("MINT_STORE_FILE", filePath)
+	return filePath
+}
+
+func TestMCPInitializeAndList(t *testing.T) {
+	c := newMCPTestClient(t, newMCPTestStore(t))
+
+	response := c.call("initialize", map[string]any{"protocolVersion": "1999-01-01"})
+	var init struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ServerInfo      struct {
+			Name string `json:"name"`
+		} `json:"serverInfo"`
+	}
+	_ = json.Unmarshal(response.Result, &init)
+	if init.ProtocolVersion != mcpProtocolVersions[0] || init.ServerInfo.Name != "mint" {
+		t.Errorf("unexpected initialize result: %s", response.Result)
+	}
+
+	response = c.call("tools/list", nil)
+	var tools struct {
+		Tools []struct {
+			Name         string         `json:"name"`
+			InputSchema  map[string]any `json:"inputSchema"`
+			OutputSchema map[string]any `json:"outputSchema"`
+		} `json:"tools"`
+	}
+	_ = json.Unmarshal(response.Result, &tools)
+	var names []string
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+		if tool.InputSchema["type"] != "object" || tool.OutputSchema["type"] != "object" {
+			t.Errorf("%s: expected object schemas", tool.Name)
+		}
+	}
+	if strings.Join(names, ",") != "create,list,show,update,close,reopen,claim,next,search" {
+		t.Errorf("unexpected tools: %v", names)
+	}
+
+	if response := c.call("bogus/method", nil); response.Error == nil || response.Error.Code != rpcMethodNotFound {
+		t.Errorf("expected method not found, got %+v", response)
+	}
+	if response := c.call("ping", nil); response.Error != nil {
+		t.Errorf("ping failed: %v", response.Error)
+	}
+}
+
+func TestMCPParseError(t *testing.T) {
+	c := newMCPTestClient(t, newMCPTestStore(t))
+
+	if _, err := fmt.Fprintln(c.in, "{not json"); err != nil {
+		t.Fatal(err)
+	}
+	response := c.read()
+	if response.Error == nil || response.Error.Code != rpcParseError || string(response.ID) != "null" {
+		t.Errorf("expected parse error, got %+v", response)
+	}
+}
+
+func TestMCPTools(t *testing.T) {
+	filePath := newMCPTestStore(t)
+	c := newMCPTestClient(t, filePath)
+
+	var first, second apiIssueResult
+	c.tool("create", map[string]any{"title": "Write parser", "description": "Handle nesting", "labels": []string{"core"}}, &first)
+	c.tool("create", map[string]any{"title": "Write docs", "depends_on": []string{first.Issue.ID}}, &second)
+	if len(first.Issue.Comments) != 1 || first.Issue.Comments[0] != "Handle nesting" {
+		t.Errorf("expected description comment, got %v", first.Issue.Comments)
+	}
+	if second.Issue.Ready || len(second.Issue.DependsOn) != 1 {
+		t.Errorf("expected docs to be blocked by the parser, got %+v", second.Issue)
+	}
+
+	// Changes are saved for the CLI to see
+	store, _ := LoadStore(filePath)
+	if len(store.Issues) != 2 {
+		t.Fatalf("expected 2 saved issues, got %d", len(store.Issues))
+	}
+
+	var ready apiIssuesResult
+	c.tool("list", map[string]any{"status": "ready"}, &ready)
+	if len(ready.Issues) != 1 || ready.Issues[0].ID != first.Issue.ID {
+		t.Errorf("expected only the parser to be ready, got %+v", ready.Issues)
+	}
+
+	var next apiIssueResult
+	c.tool("next", map[string]any{"owner": "agent-1", "claim": true}, &next)
+	if next.Issue == nil || next.Issue.ID != first.Issue.ID || next.Issue.Owner != "agent-1" {
+		t.Fatalf("expected next to claim the parser, got %+v", next.Issue)
+	}
+	c.tool("next", map[string]any{"owner": "agent-2"}, &next)
+	if next.Issue != nil {
+		t.Errorf("expected no issue for another agent, got %+v", next.Issue)
+	}
+	if result := c.toolResult("claim", map[string]any{"id": first.Issue.ID, "owner": "agent-2"}); !result.IsError {
+		t.Error("expected claiming another agent's issue to fail")
+	}
+
+	var updated apiIssueResult
+	c.tool("update", map[string]any{"id": second.Issue.ID, "comment": "Use examples", "add_labels": []string{"docs"}}, &updated)
+	if len(updated.Issue.Comments) != 1 || strings.Join(updated.Issue.Labels, ",") != "docs" {
+		t.Errorf("unexpected update result: %+v", updated.Issue)
+	}
+	if result := c.toolResult("update", map[string]any{"id": first.Issue.ID, "add_depends_on": []string{second.Issue.ID}}); !result.IsError || !strings.Contains(result.Content[0].Text, "cycle") {
+		t.Errorf("expected a cycle error, got %+v", result)
+	}
+
+	var closed apiCloseResult
+	c.tool("close", map[string]any{"id": first.Issue.ID, "reason": "done"}, &closed)
+	if closed.Issue.Status != "closed" {
+		t.Errorf("expected closed, got %s", closed.Issue.Status)
+	}
+	if len(closed.NowReady) != 1 || closed.NowReady[0].ID != second.Issue.ID || !closed.NowReady[0].Ready {
+		t.Errorf("expected docs to be ready once the parser is closed, got %+v", closed.NowReady)
+	}
+
+	var found apiIssuesResult
+	c.tool("search", map[string]any{"query": "NESTING parser"}, &found)
+	if len(found.Issues) != 0 {
+		t.Errorf("expected closed issues to be excluded, got %+v", found.Issues)
+	}
+	c.tool("search", map[string]any{"query": "NESTING parser", "include_closed": true}, &found)
+	if len(found.Issues) != 1 || found.Issues[0].ID != first.Issue.ID {
+		t.Errorf("expected the parser to match, got %+v", found.Issues)
+	}
+
+	c.tool("reopen", map[string]any{"id": first.Issue.ID}, &closed)
+	if closed.Issue.Status != "open" {
+		t.Errorf("expected reopened, got %s", closed.Issue.Status)
+	}
+
+	if result := c.toolResult("show", map[string]any{"id": first.Issue.ID, "verbose": true}); !result.IsError {
+		t.Error("expected unknown arguments to be rejected")
+	}
+	if response := c.call("tools/call", map[string]any{"name": "explode"}); response.Error == nil {
+		t.Error("expected an error for an unknown tool")
+	}
+}
+
+func TestMCPCloseDependencies(t *testing.T) {
+	filePath := newMCPTestStore(t)
+	c := newMCPTestClient(t, filePath)
+
+	var design, build, ship apiIssueResult
+	c.tool("create", map[string]any{"title": "Design"}, &design)
+	c.tool("create", map[string]any{"title": "Build", "depends_on": []string{design.Issue.ID}}, &build)
+	c.tool("create", map[string]any{"title": "Ship", "depends_on": []string{build.Issue.ID}}, &ship)
+
+	if result := c.toolResult("close", map[string]any{"id": build.Issue.ID}); !result.IsError || !strings.Contains(result.Content[0].Text, "depends on open issues") {
+		t.Errorf("expected closing with an open dependency to fail, got %+v", result)
+	}
+
+	var closed apiCloseResult
+	c.tool("close", map[string]any{"id": design.Issue.ID, "cascade": true, "resolution": "wontfix"}, &closed)
+	if len(closed.AlsoClosed) != 2 || closed.AlsoClosed[0].ID != build.Issue.ID || closed.AlsoClosed[1].Resolution != "wontfix" {
+		t.Errorf("expected build and ship to be closed along with design, got %+v", closed.AlsoClosed)
+	}
+
+	var reopened apiIssueResult
+	c.tool("reopen", map[string]any{"id": ship.Issue.ID}, &reopened)
+	c.tool("close", map[string]any{"id": ship.Issue.ID, "force": true}, &closed)
+	if closed.Issue.Status != "closed" {
+		t.Errorf("expected force to close ship, got %+v", closed.Issue)
+	}
+}
+
+func TestMCPResources(t *testing.T) {
+	filePath := newMCPTestStore(t)
+	store, _ := LoadStore(filePath)
+	blocker, _ := store.AddIssue("Blocker")
+	blocked, _ := store.AddIssue("Blocked")
+	_ = store.AddDependency(blocked.ID, blocker.ID)
+	_ = store.Save(filePath)
+
+	c := newMCPTestClient(t, filePath)
+
+	response := c.call("resources/list", nil)
+	if !strings.Contains(string(response.Result), mcpReadyURI) || !strings.Contains(string(response.Result), mcpIssueURIPrefix+blocked.ID) {
+		t.Errorf("expected ready queue and issue resources, got %s", response.Result)
+	}
+
+	read := func(uri string, v any) {
+		t.Helper()
+		response := c.call("resources/read", map[string]any{"uri": uri})
+		if response.Error != nil {
+			t.Fatalf("read %s: %s", uri, response.Error.Message)
+		}
+		var result struct {
+			Contents []struct {
+				Text string `json:"text"`
+			} `json:"contents"`
+		}
+		_ = json.Unmarshal(response.Result, &result)
+		if err := json.Unmarshal([]byte(result.Contents[0].Text), v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var ready apiIssuesResult
+	read(mcpReadyURI, &ready)
+	if len(ready.Issues) != 1 || ready.Issues[0].ID != blocker.ID {
+		t.Errorf("expected only the blocker in the ready queue, got %+v", ready.Issues)
+	}
+
+	var issue apiIssue
+	read(mcpIssueURIPrefix+blocked.ID, &issue)
+	if issue.Title != "Blocked" || issue.Ready {
+		t.Errorf("unexpected issue resource: %+v", issue)
+	}
+
+	if response := c.call("resources/read", map[string]any{"uri": "mint://nope"}); response.Error == nil {
+		t.Error("expected an error for an unknown resource")
+	}
+}
@@ -0,0 +1,408 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	mcpReadyURI       = "mint://ready"
+	mcpIssueURIPrefix = "mint://issues/"
+)
+
+// mcpTool is a tool exposed over MCP. Handler reports whether it changed
+// the store, which is then saved.
+type mcpTool struct {
+	Name         string
+	Description  string
+	InputSchema  map[string]any
+	OutputSchema map[string]any
+	Handler      func(store *Store, args json.RawMessage) (result any, changed bool, err error)
+}
+
+func objectSchema(properties map[string]any, required ...string) map[string]any {
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func stringSchema(description string) map[string]any {
+	return map[string]any{"type": "string", "description": description}
+}
+
+func stringListSchema(description string) map[string]any {
+	return map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": description}
+}
+
+var (
+	mcpResolutionSchema = map[string]any{"type": "string", "enum": resolutions, "description": "How the issue was closed"}
+
+	mcpIssueSchema = objectSchema(map[string]any{
+		"id":           stringSchema("Issue ID"),
+		"title":        stringSchema("Title"),
+		"status":       map[string]any{"type": "string", "enum": []string{"open", "closed"}},
+		"ready":        map[string]any{"type": "boolean", "description": "Open with no open dependencies"},
+		"labels":       stringListSchema("Labels"),
+		"owner":        stringSchema("Who claimed the issue"),
+		"branch":       stringSchema("Branch the issue is worked on in"),
+		"external_ref": stringSchema("Reference in another tracker"),
+		"depends_on":   stringListSchema("IDs of issues this issue depends on"),
+		"blocks":       stringListSchema("IDs of issues that depend on this issue"),
+		"comments":     stringListSchema("Comments, oldest first; the first is often the description"),
+		"created_at":   map[string]any{"type": "string", "format": "date-time"},
+		"updated_at":   map[string]any{"type": "string", "format": "date-time"},
+		"resolution":   mcpResolutionSchema,
+		"reason":       stringSchema("Why the issue was closed"),
+		"duplicate_of": stringSchema("ID of the issue this one duplicates"),
+		"closed_at":    map[string]any{"type": "string", "format": "date-time"},
+	}, "id", "title", "status", "ready", "labels", "depends_on", "blocks", "comments", "created_at", "updated_at")
+
+	mcpIssueOutputSchema  = objectSchema(map[string]any{"issue": mcpIssueSchema}, "issue")
+	mcpIssuesOutputSchema = objectSchema(map[string]any{
+		"issues": map[string]any{"type": "array", "items": mcpIssueSchema},
+	}, "issues")
+	mcpIDSchema = stringSchema("Issue ID, or a unique prefix of one")
+)
+
+// mcpTools lists the tools in the order tools/list returns them
+var mcpTools = []mcpTool{
+	{
+		Name:        "create",
+		Description: "Create an issue. The description is stored as its first comment.",
+		InputSchema: objectSchema(map[string]any{
+			"title":       stringSchema("Title"),
+			"description": stringSchema("Description"),
+			"labels":      stringListSchema("Labels"),
+			"depends_on":  stringListSchema("IDs of issues the new issue depends on"),
+			"blocks":      stringListSchema("IDs of issues that depend on the new issue"),
+		}, "title"),
+		OutputSchema: mcpIssueOutputSchema,
+		Handler:      mcpCreate,
+	},
+	{
+		Name:        "list",
+		Description: "List issues: ready first, then blocked, then closed, newest first within each.",
+		InputSchema: objectSchema(map[string]any{
+			"status": map[string]any{"type": "string", "enum": []string{"open", "ready", "blocked", "closed", "all"}, "description": "Which issues to list (default open)"},
+			"label":  stringSchema("Only issues with this label"),
+			"owner":  stringSchema("Only issues claimed by this owner"),
+			"limit":  map[string]any{"type": "integer", "minimum": 0, "description": "Maximum issues to return (0 for no limit)"},
+		}),
+		OutputSchema: mcpIssuesOutputSchema,
+		Handler:      mcpList,
+	},
+	{
+		Name:         "show",
+		Description:  "Show an issue with its relationships and comments.",
+		InputSchema:  objectSchema(map[string]any{"id": mcpIDSchema}, "id"),
+		OutputSchema: mcpIssueOutputSchema,
+		Handler:      mcpShow,
+	},
+	{
+		Name:        "update",
+		Description: "Change an issue's title, relationships, or labels, or add a comment.",
+		InputSchema: objectSchema(map[string]any{
+			"id":                mcpIDSchema,
+			"title":             stringSchema("New title"),
+			"comment":           stringSchema("Comment to add"),
+			"add_depends_on":    stringListSchema("IDs of issues to depend on"),
+			"remove_depends_on": stringListSchema("IDs of issues to stop depending on"),
+			"add_blocks":        stringListSchema("IDs of issues that should depend on this one"),
+			"remove_blocks":     stringListSchema("IDs of issues that should stop depending on this one"),
+			"add_labels":        stringListSchema("Labels to add"),
+			"remove_labels":     stringListSchema("Labels to remove"),
+		}, "id"),
+		OutputSchema: mcpIssueOutputSchema,
+		Handler:      mcpUpdate,
+	},
+	{
+		Name:        "close",
+		Description: "Close an issue with a resolution (done by default), optionally recording why. A duplicate must name the issue it duplicates. Fails if the issue depends on open issues, unless force is set. Returns the issues that became ready as a result.",
+		InputSchema: objectSchema(map[string]any{
+			"id":           mcpIDSchema,
+			"resolution":   mcpResolutionSchema,
+			"reason":       stringSchema("Why the issue was closed"),
+			"duplicate_of": stringSchema("ID of the issue this one duplicates, for a resolution of duplicate"),
+			"force":        map[string]any{"type": "boolean", "description": "Close even if the issue depends on open issues"},
+			"cascade":      map[string]any{"type": "boolean", "description": "Also close every open issue that depends on this one"},
+		}, "id"),
+		OutputSchema: objectSchema(map[string]any{
+			"issue":       mcpIssueSchema,
+			"also_closed": map[string]any{"type": "array", "items": mcpIssueSchema, "description": "Dependents closed along with it by cascade"},
+			"now_ready":   map[string]any{"type": "array", "items": mcpIssueSchema, "description": "Issues that were blocked only by this one"},
+		}, "issue", "now_ready"),
+		Handler: mcpClose,
+	},
+	{
+		Name:         "reopen",
+		Description:  "Reopen a closed issue.",
+		InputSchema:  objectSchema(map[string]any{"id": mcpIDSchema}, "id"),
+		OutputSchema: mcpIssueOutputSchema,
+		Handler:      mcpReopen,
+	},
+	{
+		Name:        "claim",
+		Description: "Claim an open issue for an owner. Fails if someone else has claimed it, unless force is set.",
+		InputSchema: objectSchema(map[string]any{
+			"id":    mcpIDSchema,
+			"owner": stringSchema("Who is working on the issue"),
+			"force": map[string]any{"type": "boolean", "description": "Take over an issue claimed by someone else"},
+		}, "id", "owner"),
+		OutputSchema: mcpIssueOutputSchema,
+		Handler:      mcpClaim,
+	},
+	{
+		Name:        "next",
+		Description: "Return the oldest ready issue not claimed by someone else, or null if there is none, optionally claiming it.",
+		InputSchema: objectSchema(map[string]any{
+			"owner": stringSchema("Who is asking; issues they already claimed are included"),
+			"claim": map[string]any{"type": "boolean", "description": "Claim the issue for owner"},
+		}),
+		OutputSchema: objectSchema(map[string]any{
+			"issue": map[string]any{"anyOf": []any{mcpIssueSchema, map[string]any{"type": "null"}}},
+		}, "issue"),
+		Handler: mcpNext,
+	},
+	{
+		Name:        "search",
+		Description: "Find issues whose ID, title, labels, or comments contain every word of the query, ignoring case.",
+		InputSchema: objectSchema(map[string]any{
+			"query":          stringSchema("Words to search for"),
+			"include_closed": map[string]any{"type": "boolean", "description": "Include closed issues"},
+			"limit":          map[string]any{"type": "integer", "minimum": 0, "description": "Maximum issues to return (0 for no limit)"},
+		}, "query"),
+		OutputSchema: mcpIssuesOutputSchema,
+		Handler:      mcpSearch,
+	},
+}
+
+func findMCPTool(name string) *mcpTool {
+	for i := range mcpTools {
+		if mcpTools[i].Name == name {
+			return &mcpTools[i]
+		}
+	}
+	return nil
+}
+
+func mcpCreate(store *Store, args json.RawMessage) (any, bool, error) {
+	var in createParams
+	if err := decodeParams(args, &in, true); err != nil {
+		return nil, false, err
+	}
+	issue, err := createIssue(store, in)
+	if err != nil {
+		return nil, false, err
+	}
+	return issueResult(store, issue), true, nil
+}
+
+func mcpList(store *Store, args json.RawMessage) (any, bool, error) {
+	var in listParams
+	if err := decodeParams(args, &in, true); err != nil {
+		return nil, false, err
+	}
+	issues, err := filterIssues(store, in)
+	if err != nil {
+		return nil, false, err
+	}
+	return issuesResult(store, issues), false, nil
+}
+
+// mcpLookup decodes arguments with an id and resolves the issue
+func mcpLookup(store *Store, args json.RawMessage, in any, id *string) (*Issue, error) {
+	if err := decodeParams(args, in, true); err != nil {
+		return nil, err
+	}
+	if *id == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+	fullID, err := store.ResolveIssueID(*id)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetIssue(fullID)
+}
+
+func mcpShow(store *Store, args json.RawMessage) (any, bool, error) {
+	var in struct {
+		ID string `json:"id"`
+	}
+	issue, err := mcpLookup(store, args, &in, &in.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	return issueResult(store, issue), false, nil
+}
+
+func mcpUpdate(store *Store, args json.RawMessage) (any, bool, error) {
+	var in struct {
+		ID string `json:"id"`
+		updateParams
+	}
+	issue, err := mcpLookup(store, args, &in, &in.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := updateIssue(store, issue, in.updateParams); err != nil {
+		return nil, false, err
+	}
+	return issueResult(store, issue), true, nil
+}
+
+func mcpClose(store *Store, args json.RawMessage) (any, bool, error) {
+	var in struct {
+		ID          string `json:"id"`
+		Resolution  string `json:"resolution"`
+		Reason      string `json:"reason"`
+		DuplicateOf string `json:"duplicate_of"`
+		Force       bool   `json:"force"`
+		Cascade     bool   `json:"cascade"`
+	}
+	issue, err := mcpLookup(store, args, &in, &in.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	if in.Resolution == "" {
+		in.Resolution = resolutionDone
+	}
+	blocked := store.blockedDependents(issue.ID)
+	closed, err := store.CloseIssues(issue.ID, in.Resolution, in.Reason, in.DuplicateOf, in.Cascade, in.Force)
+	if err != nil {
+		return nil, false, err
+	}
+	return closeResult(store, closed, store.nowReady(blocked)), true, nil
+}
+
+func mcpReopen(store *Store, args json.RawMessage) (any, bool, error) {
+	var in struct {
+		ID string `json:"id"`
+	}
+	issue, err := mcpLookup(store, args, &in, &in.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := store.ReopenIssue(issue.ID); err != nil {
+		return nil, false, err
+	}
+	return issueResult(store, issue), true, nil
+}
+
+func mcpClaim(store *Store, args json.RawMessage) (any, bool, error) {
+	var in struct {
+		ID    string `json:"id"`
+		Owner string `json:"owner"`
+		Force bool   `json:"force"`
+	}
+	issue, err := mcpLookup(store, args, &in, &in.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := claimIssue(store, issue, in.Owner, in.Force); err != nil {
+		return nil, false, err
+	}
+	return issueResult(store, issue), true, nil
+}
+
+func mcpNext(store *Store, args json.RawMessage) (any, bool, error) {
+	var in struct {
+		Owner string `json:"owner"`
+		Claim bool   `json:"claim"`
+	}
+	if err := decodeParams(args, &in, true); err != nil {
+		return nil, false, err
+	}
+	if in.Claim && strings.TrimSpace(in.Owner) == "" {
+		return nil, false, fmt.Errorf("owner is required to claim")
+	}
+
+	for _, issue := range readyQueue(store) {
+		if issue.Owner != "" && issue.Owner != in.Owner {
+			continue
+		}
+		if !in.Claim {
+			return issueResult(store, issue), false, nil
+		}
+		if err := claimIssue(store, issue, in.Owner, false); err != nil {
+			return nil, false, err
+		}
+		return issueResult(store, issue), true, nil
+	}
+	return apiIssueResult{}, false, nil
+}
+
+func mcpSearch(store *Store, args json.RawMessage) (any, bool, error) {
+	var in searchParams
+	if err := decodeParams(args, &in, true); err != nil {
+		return nil, false, err
+	}
+	matches, err := searchIssues(store, in)
+	if err != nil {
+		return nil, false, err
+	}
+	return issuesResult(store, matches), false, nil
+}
+
+func (s *mcpServer) listResources() (any, error) {
+	store, err := LoadStore(s.filePath)
+	if err != nil {
+		return nil, err
+	}
+	resources := []map[string]any{{
+		"uri":         mcpReadyURI,
+		"name":        "ready",
+		"title":       "Ready queue",
+		"description": "Open issues with no open dependencies, oldest first",
+		"mimeType":    "application/json",
+	}}
+	issues := store.ListIssues()
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Status == "open" && issues[j].Status != "open" })
+	for _, issue := range issues {
+		resources = append(resources, map[string]any{
+			"uri":      mcpIssueURIPrefix + issue.ID,
+			"name":     issue.ID,
+			"title":    issue.Title,
+			"mimeType": "application/json",
+		})
+	}
+	return map[string]any{"resources": resources}, nil
+}
+
+func (s *mcpServer) readResource(uri string) (any, error) {
+	store, err := LoadStore(s.filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	switch {
+	case uri == mcpReadyURI:
+		result = issuesResult(store, readyQueue(store))
+	case strings.HasPrefix(uri, mcpIssueURIPrefix):
+		id, err := store.ResolveIssueID(strings.TrimPrefix(uri, mcpIssueURIPrefix))
+		if err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		result = toAPIIssue(store, store.Issues[id])
+	default:
+		return nil, &rpcError{Code: rpcInvalidParams, Message: "unknown resource: " + uri}
+	}
+
+	text, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"contents": []map[string]any{{
+		"uri":      uri,
+		"mimeType": "application/json",
+		"text":     string(text),
+	}}}, nil
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// todoMarker is a TODO, FIXME, or HACK comment found in a source file
+type todoMarker struct {
+	Path string // slash-separated, relative to the scan root
+	Line int
+	Kind string
+	ID   string // issue ID from a KIND(id) marker, if any
+	Text string
+
+	kindEnd int // byte offset in the line just past Kind, for writing IDs back
+}
+
+// todoPattern matches a marker right after a comment opener such as //, #,
+// --, /* or <!--, with an optional issue ID in parentheses and colon
+var todoPattern = regexp.MustCompile(`(?://+|#+|--|;+|/\*+|<!--|^\s*\*)\s*(TODO|FIXME|HACK)\b(?:\(([^)]*)\))?:?\s*(.*)$`)
+
+var todoTrailerPattern = regexp.MustCompile(`\s*(?:\*/|-->)\s*$`)
+
+// todoExtensions are the file extensions of languages scan understands
+var todoExtensions = []string{
+	".go", ".c", ".h", ".cc", ".cpp", ".hpp", ".cs", ".java", ".kt", ".kts", ".scala", ".swift",
+	".m", ".mm", ".rs", ".zig", ".dart", ".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx", ".vue",
+	".svelte", ".css", ".scss", ".less", ".html", ".htm", ".xml", ".php", ".py", ".rb", ".pl",
+	".sh", ".bash", ".zsh", ".fish", ".lua", ".sql", ".hs", ".elm", ".ex", ".exs", ".erl",
+	".clj", ".cljs", ".el", ".lisp", ".scm", ".r", ".jl", ".nim", ".tf", ".yaml", ".yml", ".toml",
+}
+
+// todoFileNames are extensionless file names scan understands
+var todoFileNames = []string{"Makefile", "Dockerfile", "Justfile", "Rakefile", "Gemfile"}
+
+const (
+	todoRefPrefix   = "todo:"
+	maxScanFileSize = 1 << 20
+)
+
+// todoRef builds the external reference for a marker that has no issue ID
+func todoRef(m todoMarker) string {
+	return todoRefPrefix + m.Path + "#" + m.Kind + ":" + m.Text
+}
+
+// todoRefPath extracts the file path from a reference built by todoRef
+func todoRefPath(ref string) string {
+	rest := strings.TrimPrefix(ref, todoRefPrefix)
+	if i := strings.LastIndex(rest, "#"); i >= 0 {
+		return rest[:i]
+	}
+	return rest
+}
+
+// isScannable reports whether a file is in a language scan understands
+func isScannable(name string) bool {
+	base := path.Base(name)
+	return slices.Contains(todoFileNames, base) || slices.Contains(todoExtensions, strings.ToLower(path.Ext(base)))
+}
+
+// listScanFiles returns the files under paths (relative to root), respecting
+// .gitignore by asking git when root is in a git repository
+func listScanFiles(root string, paths []string) ([]string, error) {
+	args := append([]string{"-C", root, "ls-files", "-z", "--cached", "--others", "--exclude-standard", "--"}, paths...)
+	// #nosec G204 -- arguments are paths passed to git, not a shell
+	if out, err := exec.Command("git", args...).Output(); err == nil {
+		var files []string
+		for name := range strings.SplitSeq(string(out), "\x00") {
+			if name != "" && isScannable(name) {
+				files = append(files, name)
+			}
+		}
+		slices.Sort(files)
+		return slices.Compact(files), nil
+	}
+
+	// Not a git repository: walk the tree, skipping hidden directories and
+	// anything matched by a top-level .gitignore
+	ignored := readIgnorePatterns(filepath.Join(root, ".gitignore"))
+	var files []string
+	for _, p := range paths {
+		err := filepath.WalkDir(filepath.Join(root, p), func(full string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(root, full)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if rel != "." && (strings.HasPrefix(d.Name(), ".") || matchesIgnore(ignored, rel, d.IsDir())) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.IsDir() && isScannable(rel) {
+				files = append(files, rel)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	slices.Sort(files)
+	return slices.Compact(files), nil
+}
+
+// readIgnorePatterns reads the patterns of a .gitignore file
+func readIgnorePatterns(filePath string) []string {
+	// #nosec G304 -- filePath is the .gitignore at the scan root
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+	var patterns []string
+	for line := range strings.SplitSeq(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "!") {
+			patterns = append(patterns, line)
+		}
+	}
+	return patterns
+}
+
+// matchesIgnore reports whether a slash-separated path matches any of the
+// basic .gitignore patterns
+func matchesIgnore(patterns []string, rel string, isDir bool) bool {
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "/") {
+			if !isDir {
+				continue
+			}
+			pattern = strings.TrimSuffix(pattern, "/")
+		}
+		if strings.HasPrefix(pattern, "/") || strings.Contains(pattern, "/") {
+			if ok, _ := path.Match(strings.TrimPrefix(pattern, "/"), rel); ok {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// findTodoMarkers scans files (relative to root) for markers
+func findTodoMarkers(root string, files []string) ([]todoMarker, error) {
+	var markers []todoMarker
+	for _, name := range files {
+		full := filepath.Join(root, filepath.FromSlash(name))
+		info, err := os.Stat(full)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		if !info.Mode().IsRegular() || info.Size() > maxScanFileSize {
+			continue
+		}
+
+		// #nosec G304 -- full is a file inside the scanned repository
+		data, err := os.ReadFile(full)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.IndexByte(data, 0) >= 0 {
+			continue
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), maxScanFileSize)
+		lineNum := 0
+		for scanner.Scan() {
+			lineNum++
+			line := scanner.Text()
+			loc := todoPattern.FindStringSubmatchIndex(line)
+			if loc == nil {
+				continue
+			}
+			marker := todoMarker{
+				Path:    name,
+				Line:    lineNum,
+				Kind:    line[loc[2]:loc[3]],
+				Text:    strings.TrimSpace(todoTrailerPattern.ReplaceAllString(line[loc[6]:loc[7]], "")),
+				kindEnd: loc[3],
+			}
+			if loc[4] >= 0 {
+				marker.ID = strings.TrimSpace(line[loc[4]:loc[5]])
+			}
+			markers = append(markers, marker)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return markers, nil
+}
+
+// todoScanResult describes what a scan changed
+type todoScanResult struct {
+	Created []*Issue
+	Closed  []*Issue
+	// Unwritten are new markers whose issue ID can be written back
+	Unwritten map[string][]todoMarker
+}
+
+// ApplyTodoScan creates issues for new markers and closes issues whose
+// marker disappeared from the scanned paths (slash-separated, relative to
+// the scan root; "." covers everything)
+func ApplyTodoScan(store *Store, markers []todoMarker, scope []string) (*todoScanResult, error) {
+	result := &todoScanResult{Unwritten: make(map[string][]todoMarker)}
+	seen := make(map[string]bool)
+
+	for _, marker := range markers {
+		// Markers name issues in full, so one that's just a prefix of some
+		// issue's ID doesn't track it
+		if marker.ID != "" {
+			id := marker.ID
+			if _, exists := store.Issues[id]; !exists {
+				id = store.Aliases[id]
+			}
+			if _, exists := store.Issues[id]; exists {
+				seen[id] = true
+				continue
+			}
+		}
+
+		ref := todoRef(marker)
+		if issue := store.FindIssueByExternalRef(ref); issue != nil {
+			seen[issue.ID] = true
+			result.Unwritten[marker.Path] = append(result.Unwritten[marker.Path], marker)
+			continue
+		}
+
+		title := marker.Text
+		if title == "" {
+			title = fmt.Sprintf("%s in %s", marker.Kind, marker.Path)
+		}
+		issue, err := store.AddIssue(title)
+		if err != nil {
+			return nil, err
+		}
+		issue.ExternalRef = ref
+		issue.Labels = []string{strings.ToLower(marker.Kind)}
+		if err := store.AddComment(issue.ID, fmt.Sprintf("%s:%d", marker.Path, marker.Line)); err != nil {
+			return nil, err
+		}
+		seen[issue.ID] = true
+		result.Created = append(result.Created, issue)
+		result.Unwritten[marker.Path] = append(result.Unwritten[marker.Path], marker)
+	}
+
+	inScope := func(p string) bool {
+		for _, s := range scope {
+			s = strings.TrimSuffix(path.Clean(s), "/")
+			if s == "." || p == s || strings.HasPrefix(p, s+"/") {
+				return true
+			}
+		}
+		return false
+	}
+	for _, issue := range store.ListIssues() {
+		if issue.Status != "open" || seen[issue.ID] || !strings.HasPrefix(issue.ExternalRef, todoRefPrefix) {
+			continue
+		}
+		if !inScope(todoRefPath(issue.ExternalRef)) {
+			continue
+		}
+		// The marker is already gone, so open dependencies can't keep it open
+		if _, err := store.CloseIssues(issue.ID, resolutionDone, "marker removed from "+todoRefPath(issue.ExternalRef), "", false, true); err != nil {
+			return nil, err
+		}
+		result.Closed = append(result.Closed, issue)
+	}
+
+	return result, nil
+}
+
+// writeTodoIDs rewrites markers as KIND(id) so later scans track them by ID
+func writeTodoIDs(root string, store *Store, markers map[string][]todoMarker) (int, error) {
+	written := 0
+	for name, fileMarkers := range markers {
+		full := filepath.Join(root, filepath.FromSlash(name))
+		info, err := os.Stat(full)
+		if err != nil {
+			return written, err
+		}
+		// #nosec G304 -- full is a file inside the scanned repository
+		data, err := os.ReadFile(full)
+		if err != nil {
+			return written, err
+		}
+
+		lines := strings.Split(string(data), "\n")
+		for _, marker := range fileMarkers {
+			issue := store.FindIssueByExternalRef(todoRef(marker))
+			if issue == nil || marker.Line > len(lines) {
+				continue
+			}
+			line := lines[marker.Line-1]
+			if marker.kindEnd > len(line) || line[marker.kindEnd-len(marker.Kind):marker.kindEnd] != marker.Kind {
+				continue
+			}
+			rest := line[marker.kindEnd:]
+			if strings.HasPrefix(rest, "(") {
+				// Replace an unresolvable ID like TODO(someone)
+				if end := strings.Index(rest, ")"); end >= 0 {
+					rest = rest[end+1:]
+				}
+			}
+			lines[marker.Line-1] = line[:marker.kindEnd] + "(" + issue.ID + ")" + rest
+			written++
+		}
+
+		if err := os.WriteFile(full, []byte(strings.Join(lines, "\n")), info.Mode().Perm()); err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
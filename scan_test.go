@@ -0,0 +1,197 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeScanFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	full := filepath.Join(root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(full), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindTodoMarkers(t *testing.T) {
+	root := t.TempDir()
+	writeScanFile(t, root, "main.go", "package main\n\n// TODO: handle errors\nfunc main() {} // FIXME(mint-ab3) crashes on empty input\n")
+	writeScanFile(t, root, "script.py", "x = 1  # HACK work around the parser\n")
+	writeScanFile(t, root, "style.css", "/* TODO tidy up */\n")
+	writeScanFile(t, root, "notes.txt", "// TODO: not a source file\n")
+
+	markers, err := findTodoMarkers(root, []string{"main.go", "script.py", "style.css"})
+	if err != nil {
+		t.Fatalf("findTodoMarkers() failed: %v", err)
+	}
+	if len(markers) != 4 {
+		t.Fatalf("expected 4 markers, got %d: %+v", len(markers), markers)
+	}
+
+	expected := []todoMarker{
+		{Path: "main.go", Line: 3, Kind: "TODO", Text: "handle errors"},
+		{Path: "main.go", Line: 4, Kind: "FIXME", ID: "mint-ab3", Text: "crashes on empty input"},
+		{Path: "script.py", Line: 1, Kind: "HACK", Text: "work around the parser"},
+		{Path: "style.css", Line: 1, Kind: "TODO", Text: "tidy up"},
+	}
+	for i, want := range expected {
+		got := markers[i]
+		if got.Path != want.Path || got.Line != want.Line || got.Kind != want.Kind || got.ID != want.ID || got.Text != want.Text {
+			t.Errorf("marker %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+}
+
+func TestFindTodoMarkers_IgnoresNonComments(t *testing.T) {
+	root := t.TempDir()
+	writeScanFile(t, root, "main.go", "package main\n\nvar s = \"TODO: in a string\"\nvar todo = 1 // a TODO mid-sentence\n")
+
+	markers, err := findTodoMarkers(root, []string{"main.go"})
+	if err != nil {
+		t.Fatalf("findTodoMarkers() failed: %v", err)
+	}
+	if len(markers) != 0 {
+		t.Errorf("expected no markers, got %+v", markers)
+	}
+}
+
+func TestListScanFiles_WithoutGit(t *testing.T) {
+	root := t.TempDir()
+	writeScanFile(t, root, "main.go", "")
+	writeScanFile(t, root, "notes.txt", "")
+	writeScanFile(t, root, "vendor/lib.go", "")
+	writeScanFile(t, root, "build/out.js", "")
+	writeScanFile(t, root, ".hidden/secret.go", "")
+	writeScanFile(t, root, "gen.pb.go", "")
+	writeScanFile(t, root, ".gitignore", "build/\n*.pb.go\n/vendor\n")
+
+	files, err := listScanFiles(root, []string{"."})
+	if err != nil {
+		t.Fatalf("listScanFiles() failed: %v", err)
+	}
+	if strings.Join(files, ",") != "main.go" {
+		t.Errorf("expected [main.go], got %v", files)
+	}
+}
+
+func TestApplyTodoScan(t *testing.T) {
+	store := NewStore()
+	tracked, _ := store.AddIssue("Tracked by ID")
+
+	markers := []todoMarker{
+		{Path: "a.go", Line: 1, Kind: "TODO", Text: "first"},
+		{Path: "a.go", Line: 5, Kind: "FIXME", ID: tracked.ID, Text: "tracked"},
+		{Path: "b.go", Line: 2, Kind: "HACK", Text: ""},
+	}
+
+	result, err := ApplyTodoScan(store, markers, []string{"."})
+	if err != nil {
+		t.Fatalf("ApplyTodoScan() failed: %v", err)
+	}
+	if len(result.Created) != 2 {
+		t.Fatalf("expected 2 created issues, got %d", len(result.Created))
+	}
+
+	first := result.Created[0]
+	if first.Title != "first" || first.Labels[0] != "todo" || first.Comments[0] != "a.go:1" {
+		t.Errorf("unexpected issue: %+v", first)
+	}
+	if result.Created[1].Title != "HACK in b.go" {
+		t.Errorf("expected fallback title, got '%s'", result.Created[1].Title)
+	}
+
+	// Rescanning with the same markers changes nothing
+	result, err = ApplyTodoScan(store, markers, []string{"."})
+	if err != nil {
+		t.Fatalf("second ApplyTodoScan() failed: %v", err)
+	}
+	if len(result.Created) != 0 || len(result.Closed) != 0 {
+		t.Errorf("expected no changes, got %d created, %d closed", len(result.Created), len(result.Closed))
+	}
+
+	// Removing the marker from a.go closes its issue, but only when a.go is in scope
+	result, err = ApplyTodoScan(store, markers[1:], []string{"b.go"})
+	if err != nil {
+		t.Fatalf("scoped ApplyTodoScan() failed: %v", err)
+	}
+	if len(result.Closed) != 0 {
+		t.Errorf("expected out-of-scope issue to stay open, got %d closed", len(result.Closed))
+	}
+
+	result, err = ApplyTodoScan(store, markers[1:], []string{"."})
+	if err != nil {
+		t.Fatalf("ApplyTodoScan() failed: %v", err)
+	}
+	if len(result.Closed) != 1 || result.Closed[0].ID != first.ID {
+		t.Fatalf("expected %s to be closed, got %v", first.ID, result.Closed)
+	}
+	if first.Status != "closed" || first.Reason != "marker removed from a.go" {
+		t.Errorf("unexpected closed issue: %+v", first)
+	}
+	if tracked.Status != "open" {
+		t.Error("expected issue tracked by ID to stay open")
+	}
+}
+
+func TestApplyTodoScan_MarkerIDs(t *testing.T) {
+	store := NewStore()
+	renamed, _ := store.AddIssue("Tracked by an old ID")
+	other, _ := store.AddIssue("Tracked by a prefix")
+	store.addAlias("old-1", renamed.ID)
+
+	markers := []todoMarker{
+		{Path: "a.go", Line: 1, Kind: "TODO", ID: "old-1", Text: "renamed"},
+		{Path: "a.go", Line: 2, Kind: "TODO", ID: other.ID[:len(other.ID)-1], Text: "prefix"},
+	}
+	result, err := ApplyTodoScan(store, markers, []string{"."})
+	if err != nil {
+		t.Fatalf("ApplyTodoScan() failed: %v", err)
+	}
+	if len(result.Created) != 1 || result.Created[0].Title != "prefix" {
+		t.Errorf("expected an issue for the prefix marker only, got %v", result.Created)
+	}
+	if len(result.Closed) != 0 {
+		t.Errorf("expected nothing to close, got %v", result.Closed)
+	}
+}
+
+func TestWriteTodoIDs(t *testing.T) {
+	root := t.TempDir()
+	writeScanFile(t, root, "main.go", "package main\n\n// TODO: handle errors\n\t// FIXME(alice): unassigned\n")
+
+	markers, _ := findTodoMarkers(root, []string{"main.go"})
+	store := NewStore()
+	result, err := ApplyTodoScan(store, markers, []string{"."})
+	if err != nil {
+		t.Fatalf("ApplyTodoScan() failed: %v", err)
+	}
+
+	written, err := writeTodoIDs(root, store, result.Unwritten)
+	if err != nil {
+		t.Fatalf("writeTodoIDs() failed: %v", err)
+	}
+	if written != 2 {
+		t.Errorf("expected 2 IDs written, got %d", written)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(root, "main.go"))
+	expected := "package main\n\n// TODO(" + result.Created[0].ID + "): handle errors\n\t// FIXME(" + result.Created[1].ID + "): unassigned\n"
+	if string(data) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, data)
+	}
+
+	// The next scan tracks the markers by ID
+	markers, _ = findTodoMarkers(root, []string{"main.go"})
+	result, err = ApplyTodoScan(store, markers, []string{"."})
+	if err != nil {
+		t.Fatalf("ApplyTodoScan() failed: %v", err)
+	}
+	if len(result.Created) != 0 || len(result.Closed) != 0 || len(result.Unwritten) != 0 {
+		t.Errorf("expected rescan to change nothing, got %+v", result)
+	}
+}
@@ -56,35 +56,120 @@ func (s *Store) SetPrefix(newPrefix string) error {
 			}
 		}
 
+		if newOriginalID, ok := idMap[issue.DuplicateOf]; ok {
+			issue.DuplicateOf = newOriginalID
+		}
+
 		newIssues[newID] = issue
 	}
 
+	// Old IDs keep resolving as aliases, unless they're current IDs again
+	for alias, id := range s.Aliases {
+		s.Aliases[alias] = idMap[id]
+	}
+	for oldID, newID := range idMap {
+		if oldID != newID {
+			s.addAlias(oldID, newID)
+		}
+	}
+	for alias := range s.Aliases {
+		if _, exists := newIssues[alias]; exists {
+			delete(s.Aliases, alias)
+		}
+	}
+
 	s.Prefix = newPrefix
 	s.Issues = newIssues
 	return nil
 }
 
+// storeFileName is the name of the store file at a repository root
+const storeFileName = "mint-issues.yaml"
+
+// StoreLocation is where the store file lives and why
+type StoreLocation struct {
+	Path   string
+	Root   string // project root, where the repo config lives
+	Reason string
+}
+
 // GetStoreFilePath returns the path to the mint-issues.yaml file
-// Checks MINT_STORE_FILE env var first (for tests)
-// Then looks for .git walking up from current directory
-// Falls back to current directory if no .git found
+// See LocateStore for how the path is chosen
 func GetStoreFilePath() (string, error) {
+	location, err := LocateStore()
+	if err != nil {
+		return "", err
+	}
+	return location.Path, nil
+}
+
+// LocateStore finds the store file
+// Checks MINT_STORE_FILE env var first (for tests)
+// Then walks up from the current directory looking for a .mint marker or a
+// .jj, .git, or .hg repository, using the nearest one found as the project
+// root. A store setting in the config overrides the path within the root.
+// Falls back to current directory if none is found
+func LocateStore() (StoreLocation, error) {
 	// Check env var first (for tests)
 	if envPath := os.Getenv("MINT_STORE_FILE"); envPath != "" {
-		return envPath, nil
+		return StoreLocation{Path: envPath, Reason: "MINT_STORE_FILE is set"}, nil
 	}
 
-	cwd, err := os.Getwd()
+	location, err := findProjectMarker()
+	if err != nil {
+		return StoreLocation{}, err
+	}
+
+	cfg, err := loadConfigAt(location.Root)
+	if err != nil {
+		return StoreLocation{}, err
+	}
+	if path := cfg.String("store"); path != "" {
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return StoreLocation{}, err
+			}
+			path = filepath.Join(home, rest)
+		} else if !filepath.IsAbs(path) {
+			path = filepath.Join(location.Root, path)
+		}
+		return StoreLocation{Path: path, Root: location.Root, Reason: "set by store in " + cfg.Source("store")}, nil
+	}
+
+	return location, nil
+}
+
+// projectRoot returns the directory holding the project's store and
+// repository config
+func projectRoot() (string, error) {
+	if envPath := os.Getenv("MINT_STORE_FILE"); envPath != "" {
+		return filepath.Dir(envPath), nil
+	}
+	location, err := findProjectMarker()
 	if err != nil {
 		return "", err
 	}
+	return location.Root, nil
+}
+
+// findProjectMarker walks up from the current directory to the nearest
+// marker, falling back to the current directory
+func findProjectMarker() (StoreLocation, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return StoreLocation{}, err
+	}
 
-	// Walk up looking for .git
 	dir := cwd
 	for {
-		gitPath := filepath.Join(dir, ".git")
-		if _, err := os.Stat(gitPath); err == nil {
-			return filepath.Join(dir, "mint-issues.yaml"), nil
+		location, found, err := locateStoreIn(dir)
+		if err != nil {
+			return StoreLocation{}, err
+		}
+		if found {
+			location.Root = dir
+			return location, nil
 		}
 
 		parent := filepath.Dir(dir)
@@ -95,5 +180,110 @@ func GetStoreFilePath() (string, error) {
 		dir = parent
 	}
 
-	return filepath.Join(cwd, "mint-issues.yaml"), nil
+	return StoreLocation{
+		Path:   filepath.Join(cwd, storeFileName),
+		Root:   cwd,
+		Reason: "no .mint marker or repository found; using the current directory",
+	}, nil
+}
+
+// locateStoreIn checks dir for a marker and returns the store it implies
+func locateStoreIn(dir string) (StoreLocation, bool, error) {
+	// An explicit .mint.yaml or .mint marker wins over the repository. A .mint
+	// file may name the store file, relative to the directory holding it.
+	markerPath := filepath.Join(dir, ".mint")
+	if info, err := os.Stat(markerPath); err == nil {
+		if info.IsDir() {
+			return StoreLocation{Path: filepath.Join(dir, storeFileName), Reason: "found .mint directory in " + dir}, true, nil
+		}
+		// #nosec G304 -- markerPath is the .mint file in a parent directory
+		data, err := os.ReadFile(markerPath)
+		if err != nil {
+			return StoreLocation{}, false, err
+		}
+		for line := range strings.SplitSeq(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if !filepath.IsAbs(line) {
+				line = filepath.Join(dir, line)
+			}
+			return StoreLocation{Path: line, Reason: "named by .mint file in " + dir}, true, nil
+		}
+		return StoreLocation{Path: filepath.Join(dir, storeFileName), Reason: "found .mint file in " + dir}, true, nil
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, repoConfigFileName)); err == nil {
+		return StoreLocation{Path: filepath.Join(dir, storeFileName), Reason: "found " + repoConfigFileName + " in " + dir}, true, nil
+	}
+
+	if info, err := os.Stat(filepath.Join(dir, ".jj")); err == nil && info.IsDir() {
+		return StoreLocation{Path: filepath.Join(dir, storeFileName), Reason: "found jj repository in " + dir}, true, nil
+	}
+
+	gitPath := filepath.Join(dir, ".git")
+	if info, err := os.Stat(gitPath); err == nil {
+		if info.IsDir() {
+			return StoreLocation{Path: filepath.Join(dir, storeFileName), Reason: "found git repository in " + dir}, true, nil
+		}
+		return locateGitFileStore(dir, gitPath), true, nil
+	}
+
+	if info, err := os.Stat(filepath.Join(dir, ".hg")); err == nil && info.IsDir() {
+		return StoreLocation{Path: filepath.Join(dir, storeFileName), Reason: "found hg repository in " + dir}, true, nil
+	}
+
+	return StoreLocation{}, false, nil
+}
+
+// locateGitFileStore handles a .git file, which git writes for linked
+// worktrees and submodules. A submodule is its own project and keeps its own
+// store. A linked worktree uses its own checkout of the store, falling back
+// to the main worktree's store when the worktree has none (e.g. because the
+// store isn't committed).
+func locateGitFileStore(dir, gitPath string) StoreLocation {
+	location := StoreLocation{Path: filepath.Join(dir, storeFileName), Reason: "found git repository in " + dir}
+
+	// #nosec G304 -- gitPath is the .git file in a parent directory
+	data, err := os.ReadFile(gitPath)
+	if err != nil {
+		return location
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return location
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(dir, gitDir)
+	}
+
+	// Only linked worktrees have a commondir file pointing at the main .git
+	// #nosec G304 -- commondir is inside the repository's git directory
+	commonDir, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		if strings.Contains(filepath.ToSlash(gitDir), "/modules/") {
+			location.Reason = "found git submodule in " + dir
+		}
+		return location
+	}
+
+	location.Reason = "found git worktree in " + dir
+	if _, err := os.Stat(location.Path); err == nil {
+		return location
+	}
+	mainGitDir := strings.TrimSpace(string(commonDir))
+	if !filepath.IsAbs(mainGitDir) {
+		mainGitDir = filepath.Join(gitDir, mainGitDir)
+	}
+	if filepath.Base(mainGitDir) != ".git" {
+		return location
+	}
+	mainDir := filepath.Dir(mainGitDir)
+	mainPath := filepath.Join(mainDir, storeFileName)
+	if _, err := os.Stat(mainPath); err == nil {
+		return StoreLocation{Path: mainPath, Reason: "found git worktree in " + dir + "; using the store in main worktree " + mainDir}
+	}
+	return location
 }
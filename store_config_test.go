@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -82,6 +83,46 @@ func TestStoreSetPrefix_UpdatesReferences(t *testing.T) {
 	}
 }
 
+func TestStoreSetPrefix_UpdatesAliases(t *testing.T) {
+	store := NewStore()
+	store.Prefix = "old"
+	store.Issues = map[string]*Issue{
+		"old-abc123": {ID: "old-abc123", Title: "First", Status: "open"},
+	}
+	store.Aliases = map[string]string{"old-merged": "old-abc123"}
+
+	if err := store.SetPrefix("new"); err != nil {
+		t.Fatalf("SetPrefix() failed: %v", err)
+	}
+
+	if id, err := store.ResolveIssueID("old-merged"); err != nil || id != "new-abc123" {
+		t.Errorf("expected the alias to follow the renamed issue, got %q, %v", id, err)
+	}
+}
+
+func TestStoreSetPrefix_RecordsAliases(t *testing.T) {
+	store := NewStore()
+	store.Prefix = "mint"
+	store.Issues = map[string]*Issue{
+		"mint-a8": {ID: "mint-a8", Title: "First", Status: "open"},
+	}
+
+	if err := store.SetPrefix("am"); err != nil {
+		t.Fatalf("SetPrefix() failed: %v", err)
+	}
+	if id, err := store.ResolveIssueID("mint-a8"); err != nil || id != "am-a8" {
+		t.Errorf("expected the old ID to resolve to am-a8, got %q, %v", id, err)
+	}
+
+	// Changing back drops the alias that is an ID again
+	if err := store.SetPrefix("mint"); err != nil {
+		t.Fatalf("SetPrefix() failed: %v", err)
+	}
+	if len(store.Aliases) != 1 || store.Aliases["am-a8"] != "mint-a8" {
+		t.Errorf("expected only am-a8 to be an alias, got %v", store.Aliases)
+	}
+}
+
 func TestStoreSetPrefix_NormalizesHyphen(t *testing.T) {
 	store := NewStore()
 	store.Prefix = "old"
@@ -509,3 +550,172 @@ func TestGetStoreFilePath_NoGitFallback(t *testing.T) {
 		t.Errorf("expected path '%s', got '%s'", expectedPath, resolvedPath)
 	}
 }
+
+// locateFrom changes into dir and returns the located store
+func locateFrom(t *testing.T, dir string) StoreLocation {
+	t.Helper()
+	t.Setenv("MINT_STORE_FILE", "")
+	t.Chdir(dir)
+	location, err := LocateStore()
+	if err != nil {
+		t.Fatalf("LocateStore() failed: %v", err)
+	}
+	return location
+}
+
+// resolvedTempDir returns a temp dir with symlinks resolved (macOS /var -> /private/var)
+func resolvedTempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to resolve tmpdir symlinks: %v", err)
+	}
+	return dir
+}
+
+func TestLocateStore_Markers(t *testing.T) {
+	for _, marker := range []string{".jj", ".hg", ".mint"} {
+		t.Run(marker, func(t *testing.T) {
+			tmpDir := resolvedTempDir(t)
+			if err := os.Mkdir(filepath.Join(tmpDir, marker), 0o750); err != nil {
+				t.Fatal(err)
+			}
+			childDir := filepath.Join(tmpDir, "child")
+			if err := os.Mkdir(childDir, 0o750); err != nil {
+				t.Fatal(err)
+			}
+
+			location := locateFrom(t, childDir)
+			if want := filepath.Join(tmpDir, "mint-issues.yaml"); location.Path != want {
+				t.Errorf("expected path %s, got %s", want, location.Path)
+			}
+			if !strings.Contains(location.Reason, tmpDir) {
+				t.Errorf("expected reason to name %s, got %q", tmpDir, location.Reason)
+			}
+		})
+	}
+}
+
+func TestLocateStore_NearestMarkerWins(t *testing.T) {
+	tmpDir := resolvedTempDir(t)
+	childDir := filepath.Join(tmpDir, "child")
+	if err := os.MkdirAll(filepath.Join(childDir, ".jj"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(tmpDir, ".git"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	location := locateFrom(t, childDir)
+	if want := filepath.Join(childDir, "mint-issues.yaml"); location.Path != want {
+		t.Errorf("expected path %s, got %s", want, location.Path)
+	}
+	if !strings.Contains(location.Reason, "jj repository") {
+		t.Errorf("expected jj reason, got %q", location.Reason)
+	}
+}
+
+func TestLocateStore_MintFileNamesStore(t *testing.T) {
+	tmpDir := resolvedTempDir(t)
+	if err := os.Mkdir(filepath.Join(tmpDir, ".git"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".mint"), []byte("# store lives in docs\ndocs/issues.yaml\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	location := locateFrom(t, tmpDir)
+	if want := filepath.Join(tmpDir, "docs", "issues.yaml"); location.Path != want {
+		t.Errorf("expected path %s, got %s", want, location.Path)
+	}
+	if !strings.Contains(location.Reason, ".mint file") {
+		t.Errorf("expected .mint reason, got %q", location.Reason)
+	}
+}
+
+func TestLocateStore_NoMarker(t *testing.T) {
+	tmpDir := resolvedTempDir(t)
+
+	location := locateFrom(t, tmpDir)
+	if want := filepath.Join(tmpDir, "mint-issues.yaml"); location.Path != want {
+		t.Errorf("expected path %s, got %s", want, location.Path)
+	}
+	if !strings.Contains(location.Reason, "current directory") {
+		t.Errorf("expected fallback reason, got %q", location.Reason)
+	}
+}
+
+func TestLocateStore_GitSubmodule(t *testing.T) {
+	tmpDir := resolvedTempDir(t)
+	subDir := filepath.Join(tmpDir, "vendor", "lib")
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".git", "modules", "lib"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(subDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(subDir, ".git"), []byte("gitdir: ../../.git/modules/lib\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	location := locateFrom(t, subDir)
+	if want := filepath.Join(subDir, "mint-issues.yaml"); location.Path != want {
+		t.Errorf("expected path %s, got %s", want, location.Path)
+	}
+	if !strings.Contains(location.Reason, "submodule") {
+		t.Errorf("expected submodule reason, got %q", location.Reason)
+	}
+}
+
+func TestLocateStore_GitWorktree(t *testing.T) {
+	tmpDir := resolvedTempDir(t)
+	mainDir := filepath.Join(tmpDir, "main")
+	if err := os.Mkdir(mainDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	initGitRepo(t, mainDir)
+	gitCommitMessage(t, mainDir, "Initial commit")
+	worktreeDir := filepath.Join(tmpDir, "feature")
+	if _, err := runGit(mainDir, "worktree", "add", "-q", worktreeDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a store in either checkout, the worktree gets its own
+	location := locateFrom(t, worktreeDir)
+	if want := filepath.Join(worktreeDir, "mint-issues.yaml"); location.Path != want {
+		t.Errorf("expected path %s, got %s", want, location.Path)
+	}
+	if !strings.Contains(location.Reason, "worktree") {
+		t.Errorf("expected worktree reason, got %q", location.Reason)
+	}
+
+	// An uncommitted store in the main worktree is shared
+	mainStore := filepath.Join(mainDir, "mint-issues.yaml")
+	if err := NewStore().Save(mainStore); err != nil {
+		t.Fatal(err)
+	}
+	location = locateFrom(t, worktreeDir)
+	if location.Path != mainStore {
+		t.Errorf("expected path %s, got %s", mainStore, location.Path)
+	}
+	if !strings.Contains(location.Reason, "main worktree") {
+		t.Errorf("expected main worktree reason, got %q", location.Reason)
+	}
+}
+
+func TestLocateStore_ConfiguredStore(t *testing.T) {
+	tmpDir := resolvedTempDir(t)
+	writeConfigFile(t, filepath.Join(tmpDir, ".mint.yaml"), "store: docs/issues.yaml\n")
+	childDir := filepath.Join(tmpDir, "child")
+	if err := os.Mkdir(childDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	location := locateFrom(t, childDir)
+	if want := filepath.Join(tmpDir, "docs", "issues.yaml"); location.Path != want {
+		t.Errorf("expected path %s, got %s", want, location.Path)
+	}
+	if location.Reason != "set by store in repo config" {
+		t.Errorf("expected config reason, got %q", location.Reason)
+	}
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"io"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/goccy/go-yaml"
@@ -11,18 +13,45 @@ import (
 type Store struct {
 	Prefix string            `yaml:"prefix"`
 	Issues map[string]*Issue `yaml:"issues"`
+
+	// Aliases maps other names for issues, like the IDs of merged
+	// duplicates, to issue IDs
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+
+	// ID settings from config; zero values use the defaults
+	idLength   int
+	idAlphabet string
+
+	// aliasNotes is where ResolveIssueID notes that an alias was used, once
+	// per alias. The CLI points it at stderr; nil keeps quiet.
+	aliasNotes   io.Writer
+	notedAliases map[string]bool
+
+	// loaded is each issue's state when the store was loaded or last saved
+	// with saveStore, which runs hooks for what changed since
+	loaded map[string]hookState
 }
 
 // Issue represents a single issue
 type Issue struct {
-	ID        string    `yaml:"id"`
-	Title     string    `yaml:"title"`
-	Status    string    `yaml:"status"`
-	CreatedAt time.Time `yaml:"created_at"`
-	UpdatedAt time.Time `yaml:"updated_at"`
-	DependsOn []string  `yaml:"depends_on,omitempty"`
-	Blocks    []string  `yaml:"blocks,omitempty"`
-	Comments  []string  `yaml:"comments,omitempty"`
+	ID          string    `yaml:"id"`
+	Title       string    `yaml:"title"`
+	Status      string    `yaml:"status"`
+	CreatedAt   time.Time `yaml:"created_at"`
+	UpdatedAt   time.Time `yaml:"updated_at"`
+	DependsOn   []string  `yaml:"depends_on,omitempty"`
+	Blocks      []string  `yaml:"blocks,omitempty"`
+	Comments    []string  `yaml:"comments,omitempty"`
+	Labels      []string  `yaml:"labels,omitempty"`
+	ExternalRef string    `yaml:"external_ref,omitempty"`
+	Owner       string    `yaml:"owner,omitempty"`
+	Branch      string    `yaml:"branch,omitempty"`
+
+	// Set while the issue is closed
+	Resolution  string    `yaml:"resolution,omitempty"`
+	Reason      string    `yaml:"reason,omitempty"`
+	DuplicateOf string    `yaml:"duplicate_of,omitempty"`
+	ClosedAt    time.Time `yaml:"closed_at,omitempty"`
 }
 
 // NewStore creates a new store with defaults
@@ -33,6 +62,22 @@ func NewStore() *Store {
 	}
 }
 
+// openStore loads the store at filePath with the ID settings from the
+// config, for commands that create issues
+func openStore(filePath string) (*Store, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	store, err := LoadStore(filePath)
+	if err != nil {
+		return nil, err
+	}
+	store.idLength = cfg.Int("id.length")
+	store.idAlphabet = cfg.String("id.alphabet")
+	return store, nil
+}
+
 // LoadStore loads a store from a YAML file
 // If the file doesn't exist, returns a new store with defaults
 func LoadStore(filePath string) (*Store, error) {
@@ -49,18 +94,36 @@ func LoadStore(filePath string) (*Store, error) {
 	if err := yaml.Unmarshal(data, store); err != nil {
 		return nil, err
 	}
+	store.migrateResolutions()
+	store.loaded = store.hookStates()
 
 	return store, nil
 }
 
 // Save saves the store to a YAML file
+// The data is written to a temporary file first and renamed into place,
+// so readers never see a partially written store
 func (s *Store) Save(filePath string) error {
 	data, err := yaml.Marshal(s)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(filePath, data, 0o600)
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".mint-issues-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filePath)
 }
 
 // touch updates the UpdatedAt timestamp for one or more issues
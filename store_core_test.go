@@ -201,6 +201,54 @@ func TestStoreLoadOldYAML_ZeroTimestamps(t *testing.T) {
 	}
 }
 
+func TestStoreLoadOldYAML_MigratesCloseReasons(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "mint-issues.yaml")
+	data := `prefix: mint
+issues:
+  mint-a:
+    id: mint-a
+    title: Old close
+    status: closed
+    updated_at: 2024-05-01T10:00:00Z
+    comments:
+      - "Closed with reason: first"
+      - Reopened for a follow-up
+      - "Closed with reason: Shipped in 1.2"
+  mint-b:
+    id: mint-b
+    title: Closed without a reason
+    status: closed
+    updated_at: 2024-05-02T10:00:00Z
+  mint-c:
+    id: mint-c
+    title: Reopened
+    status: open
+    comments:
+      - "Closed with reason: too early"
+`
+	if err := os.WriteFile(filePath, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	store, err := LoadStore(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := store.Issues["mint-a"]
+	if a.Resolution != "done" || a.Reason != "Shipped in 1.2" || !a.ClosedAt.Equal(a.UpdatedAt) {
+		t.Errorf("expected the last reason to be migrated, got %+v", a)
+	}
+	if len(a.Comments) != 2 || a.Comments[0] != "Closed with reason: first" {
+		t.Errorf("expected earlier comments to be kept, got %v", a.Comments)
+	}
+	if b := store.Issues["mint-b"]; b.Resolution != "done" || b.Reason != "" {
+		t.Errorf("expected a resolution of done without a reason, got %+v", b)
+	}
+	if c := store.Issues["mint-c"]; c.Resolution != "" || len(c.Comments) != 1 {
+		t.Errorf("expected open issues to be left alone, got %+v", c)
+	}
+}
+
 func TestStoreLoadSave_PreservesTimestamps(t *testing.T) {
 	store := NewStore()
 	issue, _ := store.AddIssue("New issue")
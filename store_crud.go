@@ -3,18 +3,24 @@ package main
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
+	"unicode"
 )
 
 // AddIssue creates a new issue with a unique ID and adds it to the store
 func (s *Store) AddIssue(title string) (*Issue, error) {
 	const maxRetries = 10
 
-	length := CalculateIDLength(len(s.Issues))
+	alphabet := s.idAlphabet
+	if alphabet == "" {
+		alphabet = customAlphabet
+	}
+	length := max(calculateIDLength(len(s.Issues), len(alphabet)), s.idLength)
 
 	var id string
 	for i := range maxRetries {
-		id = GenerateID(s.Prefix, length)
+		id = generateID(s.Prefix, alphabet, length)
 		if _, exists := s.Issues[id]; !exists {
 			break
 		}
@@ -37,7 +43,7 @@ func (s *Store) AddIssue(title string) (*Issue, error) {
 	return issue, nil
 }
 
-// ResolveIssueID resolves a partial ID to a full ID
+// ResolveIssueID resolves a partial ID or an alias to a full ID
 // Returns error if ambiguous or not found
 func (s *Store) ResolveIssueID(partialID string) (string, error) {
 	// Check exact match first
@@ -45,6 +51,20 @@ func (s *Store) ResolveIssueID(partialID string) (string, error) {
 		return partialID, nil
 	}
 
+	// Then an alias, like an ID from before set-prefix or a merge
+	if id, ok := s.Aliases[partialID]; ok {
+		if _, exists := s.Issues[id]; exists {
+			if s.aliasNotes != nil && !s.notedAliases[partialID] {
+				fmt.Fprintf(s.aliasNotes, "note: %s is an alias for %s\n", partialID, id)
+				if s.notedAliases == nil {
+					s.notedAliases = make(map[string]bool)
+				}
+				s.notedAliases[partialID] = true
+			}
+			return id, nil
+		}
+	}
+
 	// Check for prefix matches
 	var matches []string
 	for id := range s.Issues {
@@ -109,6 +129,20 @@ func (s *Store) FormatID(id string) string {
 	return FormatID(id, uniqueLengths[id])
 }
 
+// FindIssueByExternalRef returns the issue imported from the given external
+// reference, or nil if no issue has it
+func (s *Store) FindIssueByExternalRef(ref string) *Issue {
+	if ref == "" {
+		return nil
+	}
+	for _, issue := range s.Issues {
+		if issue.ExternalRef == ref {
+			return issue
+		}
+	}
+	return nil
+}
+
 // UpdateIssueTitle updates an issue's title
 func (s *Store) UpdateIssueTitle(id, title string) error {
 	issue, err := s.GetIssue(id)
@@ -119,3 +153,41 @@ func (s *Store) UpdateIssueTitle(id, title string) error {
 	s.touch(issue)
 	return nil
 }
+
+// addAlias makes alias resolve to id
+func (s *Store) addAlias(alias, id string) {
+	if s.Aliases == nil {
+		s.Aliases = make(map[string]string)
+	}
+	s.Aliases[alias] = id
+}
+
+// AddAlias adds a name that resolves to an issue, like a human-friendly
+// name or an ID the issue used to have. An alias for another issue is only
+// replaced if replace is set. Returns the issue.
+func (s *Store) AddAlias(alias, id string, replace bool) (*Issue, error) {
+	if alias == "" || strings.IndexFunc(alias, unicode.IsSpace) >= 0 {
+		return nil, fmt.Errorf("invalid alias %q (must be non-empty without spaces)", alias)
+	}
+	if _, exists := s.Issues[alias]; exists {
+		return nil, fmt.Errorf("%s is already an issue ID", alias)
+	}
+	issue, err := s.GetIssue(id)
+	if err != nil {
+		return nil, err
+	}
+	if current, ok := s.Aliases[alias]; ok && current != issue.ID && s.Issues[current] != nil && !replace {
+		return nil, fmt.Errorf("alias %s already refers to %s (use --force to replace it)", alias, current)
+	}
+	s.addAlias(alias, issue.ID)
+	return issue, nil
+}
+
+// RemoveAlias removes an alias
+func (s *Store) RemoveAlias(alias string) error {
+	if _, ok := s.Aliases[alias]; !ok {
+		return fmt.Errorf("alias %s not found", alias)
+	}
+	delete(s.Aliases, alias)
+	return nil
+}
@@ -126,6 +126,78 @@ func TestStoreResolveIssueID_NotFound(t *testing.T) {
 	}
 }
 
+func TestStoreResolveIssueID_Alias(t *testing.T) {
+	store := NewStore()
+	store.Issues = map[string]*Issue{
+		"mint-abc123": {ID: "mint-abc123", Title: "First", Status: "open"},
+		"mint-def456": {ID: "mint-def456", Title: "Second", Status: "open"},
+	}
+	store.Aliases = map[string]string{
+		"mint-old":    "mint-abc123",
+		"mint-def456": "mint-abc123",
+		"mint-gone":   "mint-xyz789",
+	}
+
+	if id, err := store.ResolveIssueID("mint-old"); err != nil || id != "mint-abc123" {
+		t.Errorf("expected the alias to resolve to mint-abc123, got %q, %v", id, err)
+	}
+	if id, _ := store.ResolveIssueID("mint-def456"); id != "mint-def456" {
+		t.Errorf("expected an issue ID to win over an alias, got %q", id)
+	}
+	if _, err := store.ResolveIssueID("mint-gone"); err == nil {
+		t.Error("expected an alias for a missing issue not to resolve")
+	}
+}
+
+func TestStoreAddAlias(t *testing.T) {
+	store := NewStore()
+	store.Issues = map[string]*Issue{
+		"mint-abc123": {ID: "mint-abc123", Title: "First", Status: "open"},
+		"mint-def456": {ID: "mint-def456", Title: "Second", Status: "open"},
+	}
+
+	for _, alias := range []string{"", "two words", "mint-def456"} {
+		if _, err := store.AddAlias(alias, "mint-abc123", false); err == nil {
+			t.Errorf("expected alias %q to be rejected", alias)
+		}
+	}
+	if _, err := store.AddAlias("parser", "mint-xyz", false); err == nil {
+		t.Error("expected an alias for a missing issue to be rejected")
+	}
+
+	issue, err := store.AddAlias("parser", "mint-abc", false)
+	if err != nil {
+		t.Fatalf("AddAlias() failed: %v", err)
+	}
+	if issue.ID != "mint-abc123" || store.Aliases["parser"] != "mint-abc123" {
+		t.Errorf("expected the alias to name the full ID, got %v", store.Aliases)
+	}
+
+	// Moving an alias to another issue has to be asked for
+	if _, err := store.AddAlias("parser", "mint-abc123", false); err != nil {
+		t.Errorf("expected re-adding the same alias to succeed, got %v", err)
+	}
+	if _, err := store.AddAlias("parser", "mint-def456", false); err == nil || !strings.Contains(err.Error(), "already refers to mint-abc123") {
+		t.Errorf("expected moving the alias to be refused, got %v", err)
+	}
+	if store.Aliases["parser"] != "mint-abc123" {
+		t.Errorf("expected the refused alias to be unchanged, got %v", store.Aliases)
+	}
+	if _, err := store.AddAlias("parser", "mint-def456", true); err != nil || store.Aliases["parser"] != "mint-def456" {
+		t.Errorf("expected replace to move the alias, got %v (%v)", store.Aliases, err)
+	}
+
+	if err := store.RemoveAlias("parser"); err != nil {
+		t.Fatalf("RemoveAlias() failed: %v", err)
+	}
+	if _, err := store.ResolveIssueID("parser"); err == nil {
+		t.Error("expected the removed alias not to resolve")
+	}
+	if err := store.RemoveAlias("parser"); err == nil {
+		t.Error("expected removing a missing alias to fail")
+	}
+}
+
 func TestStoreGetIssue_WithPrefix(t *testing.T) {
 	store := NewStore()
 	store.Issues = map[string]*Issue{
@@ -1,6 +1,11 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
 
 // AddComment adds a comment to an issue
 func (s *Store) AddComment(id, comment string) error {
@@ -13,27 +18,198 @@ func (s *Store) AddComment(id, comment string) error {
 	return nil
 }
 
-// CloseIssue closes an issue, optionally with a reason
+// Resolutions say why an issue was closed
+const (
+	resolutionDone            = "done"
+	resolutionWontfix         = "wontfix"
+	resolutionDuplicate       = "duplicate"
+	resolutionObsolete        = "obsolete"
+	resolutionCannotReproduce = "cannot-reproduce"
+)
+
+// resolutions lists the valid resolutions in the order they are offered
+var resolutions = []string{resolutionDone, resolutionWontfix, resolutionDuplicate, resolutionObsolete, resolutionCannotReproduce}
+
+// legacyReasonPrefix starts the comment closing used to add before
+// resolutions were recorded on the issue
+const legacyReasonPrefix = "Closed with reason: "
+
+// CloseIssue closes an issue as done, optionally with a reason
 func (s *Store) CloseIssue(id, reason string) error {
+	return s.CloseIssueAs(id, resolutionDone, reason, "")
+}
+
+// CloseIssueAs closes an issue with a resolution and an optional reason.
+// A duplicate must name the issue it duplicates in duplicateOf.
+func (s *Store) CloseIssueAs(id, resolution, reason, duplicateOf string) error {
 	issue, err := s.GetIssue(id)
 	if err != nil {
 		return fmt.Errorf("issue not found: %s", id)
 	}
+	if duplicateOf, err = s.checkResolution(issue, resolution, duplicateOf); err != nil {
+		return err
+	}
+
 	issue.Status = "closed"
-	if reason != "" {
-		issue.Comments = append(issue.Comments, fmt.Sprintf("Closed with reason: %s", reason))
+	issue.Resolution = resolution
+	issue.Reason = reason
+	issue.DuplicateOf = duplicateOf
+	issue.ClosedAt = time.Now()
+	s.touch(issue)
+	return nil
+}
+
+// checkResolution checks that issue can be closed with resolution, and
+// returns the full ID of the issue it duplicates
+func (s *Store) checkResolution(issue *Issue, resolution, duplicateOf string) (string, error) {
+	if !slices.Contains(resolutions, resolution) {
+		return "", fmt.Errorf("invalid resolution %q (must be one of %s)", resolution, strings.Join(resolutions, ", "))
+	}
+	if resolution != resolutionDuplicate {
+		if duplicateOf != "" {
+			return "", fmt.Errorf("only a %s can name the issue it duplicates", resolutionDuplicate)
+		}
+		return "", nil
+	}
+	if duplicateOf == "" {
+		return "", fmt.Errorf("closing as %s requires the issue it duplicates", resolutionDuplicate)
+	}
+	original, err := s.GetIssue(duplicateOf)
+	if err != nil {
+		return "", err
+	}
+	if original == issue {
+		return "", fmt.Errorf("issue %s can't be a duplicate of itself", issue.ID)
+	}
+	return original.ID, nil
+}
+
+// migrateResolutions gives issues closed before resolutions were recorded a
+// resolution of done, taking the reason from the comment closing added and
+// the close time from the last update
+func (s *Store) migrateResolutions() {
+	for _, issue := range s.Issues {
+		if issue.Status != "closed" || issue.Resolution != "" {
+			continue
+		}
+		issue.Resolution = resolutionDone
+		if issue.ClosedAt.IsZero() {
+			issue.ClosedAt = issue.UpdatedAt
+		}
+		for i := len(issue.Comments) - 1; i >= 0; i-- {
+			if reason, ok := strings.CutPrefix(issue.Comments[i], legacyReasonPrefix); ok {
+				issue.Reason = reason
+				issue.Comments = slices.Delete(issue.Comments, i, i+1)
+				break
+			}
+		}
+	}
+}
+
+// CloseIssues closes an issue and, with cascade, every open issue that
+// depends on it, directly or through other dependents. Unless force is
+// set, it refuses if any of them depends on an open issue that isn't being
+// closed with it. Dependents are closed with the same resolution, except
+// that the dependents of a duplicate are obsolete. Returns the closed
+// issues, starting with id's.
+func (s *Store) CloseIssues(id, resolution, reason, duplicateOf string, cascade, force bool) ([]*Issue, error) {
+	issue, err := s.GetIssue(id)
+	if err != nil {
+		return nil, err
+	}
+
+	closing := []*Issue{issue}
+	if cascade {
+		for i := 0; i < len(closing); i++ {
+			for _, dependentID := range closing[i].Blocks {
+				dependent := s.Issues[dependentID]
+				if dependent != nil && dependent.Status == "open" && !slices.Contains(closing, dependent) {
+					closing = append(closing, dependent)
+				}
+			}
+		}
+	}
+
+	if !force {
+		for _, issue := range closing {
+			var open []string
+			for _, depID := range issue.DependsOn {
+				dep := s.Issues[depID]
+				if dep != nil && dep.Status == "open" && !slices.Contains(closing, dep) {
+					open = append(open, depID)
+				}
+			}
+			if len(open) > 0 {
+				return nil, fmt.Errorf("issue %s depends on open issues: %s (close them first, or force the close)", issue.ID, strings.Join(open, ", "))
+			}
+		}
+	}
+
+	if err := s.CloseIssueAs(issue.ID, resolution, reason, duplicateOf); err != nil {
+		return nil, err
+	}
+	if resolution == resolutionDuplicate {
+		resolution = resolutionObsolete
+	}
+	for _, dependent := range closing[1:] {
+		dependentReason := strings.TrimSpace(fmt.Sprintf("%s (closed along with %s)", reason, issue.ID))
+		if err := s.CloseIssueAs(dependent.ID, resolution, dependentReason, ""); err != nil {
+			return nil, err
+		}
+	}
+	return closing, nil
+}
+
+// StartIssue claims an open issue for owner and records the branch it is
+// being worked on
+func (s *Store) StartIssue(id, owner, branch string) error {
+	issue, err := s.GetIssue(id)
+	if err != nil {
+		return fmt.Errorf("issue not found: %s", id)
+	}
+	if issue.Status == "closed" {
+		return fmt.Errorf("issue %s is closed", id)
 	}
+	issue.Owner = owner
+	issue.Branch = branch
 	s.touch(issue)
 	return nil
 }
 
-// ReopenIssue reopens a closed issue
+// FindIssueByBranch returns the issue being worked on in branch, matching
+// the recorded branch first and then a branch named after an issue ID
+// Returns nil if no issue matches
+func (s *Store) FindIssueByBranch(branch string) *Issue {
+	if branch == "" {
+		return nil
+	}
+	var byName *Issue
+	for _, issue := range s.ListIssues() {
+		if issue.Branch == branch {
+			return issue
+		}
+		if byName == nil && (branch == issue.ID || strings.HasPrefix(branch, issue.ID+"-")) {
+			byName = issue
+		}
+	}
+	return byName
+}
+
+// ReopenIssue reopens a closed issue. A reason it was closed with is kept
+// as a comment.
 func (s *Store) ReopenIssue(id string) error {
 	issue, err := s.GetIssue(id)
 	if err != nil {
 		return fmt.Errorf("issue not found: %s", id)
 	}
+	if issue.Reason != "" {
+		issue.Comments = append(issue.Comments, fmt.Sprintf("Reopened; was closed as %s: %s", resolutionText(issue), issue.Reason))
+	}
 	issue.Status = "open"
+	issue.Resolution = ""
+	issue.Reason = ""
+	issue.DuplicateOf = ""
+	issue.ClosedAt = time.Time{}
 	s.touch(issue)
 	return nil
 }
@@ -73,6 +249,140 @@ func (s *Store) DeleteIssue(id string) error {
 		}
 	}
 
+	// Aliases can't outlive the issue they name
+	for alias, id := range s.Aliases {
+		if id == fullID {
+			delete(s.Aliases, alias)
+		}
+	}
+
 	delete(s.Issues, fullID)
 	return nil
 }
+
+// MergeIssue folds the duplicate dupID into keepID and deletes it. The
+// comments and labels the kept issue doesn't have are added to it, every
+// issue related to the duplicate is related to the kept issue instead, and
+// the duplicate's ID becomes an alias for the kept issue.
+func (s *Store) MergeIssue(keepID, dupID string) error {
+	keep, err := s.GetIssue(keepID)
+	if err != nil {
+		return err
+	}
+	dup, err := s.GetIssue(dupID)
+	if err != nil {
+		return err
+	}
+	if keep == dup {
+		return fmt.Errorf("can't merge issue %s into itself", keep.ID)
+	}
+
+	// Check the merged graph for cycles before changing anything
+	graph := s.dependencyGraph()
+	graph[keep.ID] = append(graph[keep.ID], graph[dup.ID]...)
+	delete(graph, dup.ID)
+	for id, deps := range graph {
+		merged := make([]string, 0, len(deps))
+		for _, depID := range deps {
+			if depID == dup.ID {
+				depID = keep.ID
+			}
+			if depID != id && !slices.Contains(merged, depID) {
+				merged = append(merged, depID)
+			}
+		}
+		graph[id] = merged
+	}
+	if cycle := findCycle(graph); cycle != nil {
+		return fmt.Errorf("merging would create a dependency cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	keep.Comments = append(keep.Comments, fmt.Sprintf("Merged %s: %s", dup.ID, dup.Title))
+	for _, comment := range dup.Comments {
+		if !slices.Contains(keep.Comments, comment) {
+			keep.Comments = append(keep.Comments, comment)
+		}
+	}
+	for _, label := range dup.Labels {
+		if !slices.Contains(keep.Labels, label) {
+			keep.Labels = append(keep.Labels, label)
+		}
+	}
+	if keep.Owner == "" {
+		keep.Owner, keep.Branch = dup.Owner, dup.Branch
+	}
+	if keep.ExternalRef == "" {
+		keep.ExternalRef = dup.ExternalRef
+	}
+
+	// Deleting the duplicate removes its edges, which are then added back
+	// to the kept issue, skipping ones it has and ones to itself
+	dependsOn, blocks := dup.DependsOn, dup.Blocks
+	// The kept issue stands in for the duplicate now, so if it was closed as
+	// a duplicate of it, it takes the duplicate's status instead
+	if keep.DuplicateOf == dup.ID {
+		if dup.Status == "closed" && dup.DuplicateOf != keep.ID {
+			keep.Resolution, keep.Reason, keep.DuplicateOf, keep.ClosedAt = dup.Resolution, dup.Reason, dup.DuplicateOf, dup.ClosedAt
+		} else if err := s.ReopenIssue(keep.ID); err != nil {
+			return err
+		}
+	}
+	for _, issue := range s.Issues {
+		if issue != keep && issue.DuplicateOf == dup.ID {
+			issue.DuplicateOf = keep.ID
+		}
+	}
+	for alias, id := range s.Aliases {
+		if id == dup.ID {
+			s.Aliases[alias] = keep.ID
+		}
+	}
+	if err := s.DeleteIssue(dup.ID); err != nil {
+		return err
+	}
+	for _, depID := range dependsOn {
+		if depID != keep.ID && s.Issues[depID] != nil && !slices.Contains(keep.DependsOn, depID) {
+			if err := s.AddDependency(keep.ID, depID); err != nil {
+				return err
+			}
+		}
+	}
+	for _, blockedID := range blocks {
+		if blockedID != keep.ID && s.Issues[blockedID] != nil && !slices.Contains(keep.Blocks, blockedID) {
+			if err := s.AddBlocker(keep.ID, blockedID); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.addAlias(dup.ID, keep.ID)
+	s.touch(keep)
+	return nil
+}
+
+// RelinkDependents makes each issue that depends on id also depend on
+// id's own dependencies, so deleting id keeps the chain between them.
+// Returns the new dependencies as pairs of dependent and dependency IDs.
+func (s *Store) RelinkDependents(id string) ([][2]string, error) {
+	issue, err := s.GetIssue(id)
+	if err != nil {
+		return nil, err
+	}
+	var added [][2]string
+	for _, dependentID := range issue.Blocks {
+		dependent := s.Issues[dependentID]
+		if dependent == nil {
+			continue
+		}
+		for _, depID := range issue.DependsOn {
+			if depID == dependentID || slices.Contains(dependent.DependsOn, depID) {
+				continue
+			}
+			if err := s.AddDependency(dependentID, depID); err != nil {
+				return nil, err
+			}
+			added = append(added, [2]string{dependentID, depID})
+		}
+	}
+	return added, nil
+}
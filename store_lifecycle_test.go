@@ -1,6 +1,8 @@
 package main
 
 import (
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -42,13 +44,50 @@ func TestStoreCloseIssue_WithReason(t *testing.T) {
 		t.Errorf("expected status 'closed', got '%s'", issue.Status)
 	}
 
-	if len(issue.Comments) != 1 {
-		t.Fatalf("expected 1 comment, got %d", len(issue.Comments))
+	if issue.Resolution != "done" || issue.Reason != "Done" || issue.ClosedAt.IsZero() {
+		t.Errorf("expected a resolution of done with the reason, got %q %q %v", issue.Resolution, issue.Reason, issue.ClosedAt)
+	}
+	if len(issue.Comments) != 0 {
+		t.Errorf("expected no comments, got %v", issue.Comments)
+	}
+}
+
+func TestStoreCloseIssueAs(t *testing.T) {
+	store := NewStore()
+	original, _ := store.AddIssue("Parser crashes")
+	duplicate, _ := store.AddIssue("Crash in parser")
+
+	if err := store.CloseIssueAs(duplicate.ID, "fixed", "", ""); err == nil || !strings.Contains(err.Error(), "invalid resolution") {
+		t.Errorf("expected an unknown resolution to be rejected, got %v", err)
+	}
+	if err := store.CloseIssueAs(duplicate.ID, "duplicate", "", ""); err == nil {
+		t.Errorf("expected a duplicate without an original to be rejected")
+	}
+	if err := store.CloseIssueAs(duplicate.ID, "duplicate", "", duplicate.ID); err == nil {
+		t.Errorf("expected an issue not to duplicate itself")
+	}
+	if err := store.CloseIssueAs(duplicate.ID, "wontfix", "", original.ID); err == nil {
+		t.Errorf("expected only duplicates to name an original")
+	}
+	if duplicate.Status != "open" {
+		t.Fatalf("expected rejected closes to change nothing")
 	}
 
-	expectedComment := "Closed with reason: Done"
-	if issue.Comments[0] != expectedComment {
-		t.Errorf("expected comment '%s', got '%s'", expectedComment, issue.Comments[0])
+	// The original can be given as a partial ID
+	if err := store.CloseIssueAs(duplicate.ID, "duplicate", "Same stack trace", original.ID[:len(original.ID)-1]); err != nil {
+		t.Fatalf("CloseIssueAs() failed: %v", err)
+	}
+	if duplicate.Status != "closed" || duplicate.Resolution != "duplicate" || duplicate.DuplicateOf != original.ID || duplicate.Reason != "Same stack trace" {
+		t.Errorf("unexpected issue after closing: %+v", duplicate)
+	}
+
+	// Reopening clears the resolution, keeping the reason as a comment
+	_ = store.ReopenIssue(duplicate.ID)
+	if duplicate.Resolution != "" || duplicate.Reason != "" || duplicate.DuplicateOf != "" || !duplicate.ClosedAt.IsZero() {
+		t.Errorf("expected the resolution to be cleared, got %+v", duplicate)
+	}
+	if want := "Reopened; was closed as duplicate of " + original.ID + ": Same stack trace"; len(duplicate.Comments) != 1 || duplicate.Comments[0] != want {
+		t.Errorf("expected %q, got %v", want, duplicate.Comments)
 	}
 }
 
@@ -215,3 +254,222 @@ func TestStoreReopenIssue_UpdatesTimestamp(t *testing.T) {
 		t.Errorf("UpdatedAt should be after original")
 	}
 }
+
+func TestStoreStartIssue(t *testing.T) {
+	store := NewStore()
+	issue, _ := store.AddIssue("Test issue")
+
+	if err := store.StartIssue(issue.ID, "alice", "mint-abc-test-issue"); err != nil {
+		t.Fatalf("StartIssue() failed: %v", err)
+	}
+	if issue.Owner != "alice" || issue.Branch != "mint-abc-test-issue" {
+		t.Errorf("expected owner and branch to be recorded, got %q and %q", issue.Owner, issue.Branch)
+	}
+
+	_ = store.CloseIssue(issue.ID, "")
+	if err := store.StartIssue(issue.ID, "bob", "other"); err == nil {
+		t.Error("expected error starting a closed issue")
+	}
+}
+
+func TestStoreFindIssueByBranch(t *testing.T) {
+	store := NewStore()
+	store.Issues["mint-abc"] = &Issue{ID: "mint-abc", Status: "open", Branch: "feature/parser"}
+	store.Issues["mint-def"] = &Issue{ID: "mint-def", Status: "open"}
+
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{"feature/parser", "mint-abc"},
+		{"mint-def-fix-lexer", "mint-def"},
+		{"mint-def", "mint-def"},
+		{"mint-define-things", ""},
+		{"main", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got := store.FindIssueByBranch(tt.branch)
+		gotID := ""
+		if got != nil {
+			gotID = got.ID
+		}
+		if gotID != tt.want {
+			t.Errorf("FindIssueByBranch(%q) = %q, want %q", tt.branch, gotID, tt.want)
+		}
+	}
+}
+
+func TestStoreCloseIssues(t *testing.T) {
+	newChain := func() (*Store, *Issue, *Issue, *Issue, *Issue) {
+		store := NewStore()
+		design, _ := store.AddIssue("Design")
+		build, _ := store.AddIssue("Build")
+		ship, _ := store.AddIssue("Ship")
+		review, _ := store.AddIssue("Review")
+		_ = store.AddDependency(build.ID, design.ID)
+		_ = store.AddDependency(ship.ID, build.ID)
+		_ = store.AddDependency(ship.ID, review.ID)
+		return store, design, build, ship, review
+	}
+
+	store, design, build, _, _ := newChain()
+	if _, err := store.CloseIssues(build.ID, "done", "", "", false, false); err == nil || !strings.Contains(err.Error(), "depends on open issues: "+design.ID) {
+		t.Errorf("expected closing with an open dependency to be refused, got %v", err)
+	}
+	if build.Status != "open" {
+		t.Errorf("expected a refused close to change nothing")
+	}
+	if _, err := store.CloseIssues(build.ID, "done", "", "", false, true); err != nil || build.Status != "closed" {
+		t.Errorf("expected force to close anyway, got %v", err)
+	}
+
+	// Cascading still checks the dependencies of everything it closes
+	store, design, build, ship, review := newChain()
+	if _, err := store.CloseIssues(design.ID, "done", "", "", true, false); err == nil || !strings.Contains(err.Error(), ship.ID+" depends on open issues: "+review.ID) {
+		t.Errorf("expected the review to block the cascade, got %v", err)
+	}
+	_ = store.CloseIssue(review.ID, "")
+	closed, err := store.CloseIssues(design.ID, "duplicate", "dropped", review.ID, true, false)
+	if err != nil {
+		t.Fatalf("CloseIssues() failed: %v", err)
+	}
+	if len(closed) != 3 || closed[0] != design || closed[1] != build || closed[2] != ship {
+		t.Errorf("expected design, build, and ship to be closed, got %v", closed)
+	}
+	if design.Resolution != "duplicate" || design.DuplicateOf != review.ID {
+		t.Errorf("expected design to be a duplicate of the review, got %+v", design)
+	}
+	if want := "dropped (closed along with " + design.ID + ")"; ship.Resolution != "obsolete" || ship.Reason != want || ship.DuplicateOf != "" {
+		t.Errorf("expected ship to be obsolete with %q, got %+v", want, ship)
+	}
+}
+
+func TestStoreRelinkDependents(t *testing.T) {
+	store := NewStore()
+	design, _ := store.AddIssue("Design")
+	spike, _ := store.AddIssue("Spike")
+	build, _ := store.AddIssue("Build")
+	_ = store.AddDependency(spike.ID, design.ID)
+	_ = store.AddDependency(build.ID, spike.ID)
+
+	added, err := store.RelinkDependents(spike.ID)
+	if err != nil {
+		t.Fatalf("RelinkDependents() failed: %v", err)
+	}
+	if len(added) != 1 || added[0] != [2]string{build.ID, design.ID} {
+		t.Errorf("expected build to depend on design, got %v", added)
+	}
+	_ = store.DeleteIssue(spike.ID)
+	if len(build.DependsOn) != 1 || build.DependsOn[0] != design.ID || len(design.Blocks) != 1 || design.Blocks[0] != build.ID {
+		t.Errorf("expected the chain to be kept, got %v and %v", build.DependsOn, design.Blocks)
+	}
+}
+
+func TestStoreMergeIssue(t *testing.T) {
+	store := NewStore()
+	keep, _ := store.AddIssue("Parser crashes")
+	dup, _ := store.AddIssue("Crash in parser")
+	design, _ := store.AddIssue("Design")
+	docs, _ := store.AddIssue("Docs")
+	release, _ := store.AddIssue("Release")
+	_ = store.AddComment(keep.ID, "Seen on empty input")
+	_ = store.AddComment(dup.ID, "Seen on empty input")
+	_ = store.AddComment(dup.ID, "Stack trace attached")
+	keep.Labels = []string{"bug"}
+	dup.Labels = []string{"bug", "parser"}
+	_ = store.AddDependency(keep.ID, design.ID)
+	_ = store.AddDependency(dup.ID, design.ID)
+	_ = store.AddDependency(dup.ID, docs.ID)
+	_ = store.AddDependency(release.ID, dup.ID)
+	_ = store.AddDependency(release.ID, keep.ID)
+	_ = store.CloseIssueAs(docs.ID, resolutionDuplicate, "", dup.ID)
+
+	if err := store.MergeIssue(keep.ID, keep.ID); err == nil {
+		t.Error("expected merging an issue into itself to fail")
+	}
+	if err := store.MergeIssue(keep.ID, dup.ID); err != nil {
+		t.Fatalf("MergeIssue() failed: %v", err)
+	}
+
+	if _, exists := store.Issues[dup.ID]; exists {
+		t.Error("expected the duplicate to be deleted")
+	}
+	wantComments := []string{"Seen on empty input", "Merged " + dup.ID + ": Crash in parser", "Stack trace attached"}
+	if !slices.Equal(keep.Comments, wantComments) {
+		t.Errorf("expected comments %v, got %v", wantComments, keep.Comments)
+	}
+	if !slices.Equal(keep.Labels, []string{"bug", "parser"}) {
+		t.Errorf("expected labels to be combined, got %v", keep.Labels)
+	}
+	if !slices.Equal(keep.DependsOn, []string{design.ID, docs.ID}) || !slices.Equal(keep.Blocks, []string{release.ID}) {
+		t.Errorf("expected the duplicate's edges once each, got depends on %v and blocks %v", keep.DependsOn, keep.Blocks)
+	}
+	if !slices.Equal(release.DependsOn, []string{keep.ID}) || !slices.Equal(design.Blocks, []string{keep.ID}) {
+		t.Errorf("expected related issues to point at the kept issue, got %v and %v", release.DependsOn, design.Blocks)
+	}
+	if docs.DuplicateOf != keep.ID {
+		t.Errorf("expected duplicates of the duplicate to point at the kept issue, got %q", docs.DuplicateOf)
+	}
+
+	// The duplicate's ID still resolves
+	if id, err := store.ResolveIssueID(dup.ID); err != nil || id != keep.ID {
+		t.Errorf("expected %s to resolve to %s, got %q, %v", dup.ID, keep.ID, id, err)
+	}
+}
+
+func TestStoreMergeIssue_IntoItsDuplicate(t *testing.T) {
+	store := NewStore()
+	keep, _ := store.AddIssue("Crash in parser")
+	dup, _ := store.AddIssue("Parser crashes")
+	_ = store.CloseIssueAs(keep.ID, resolutionDuplicate, "", dup.ID)
+
+	// Keeping the issue that was closed as a duplicate reopens it, since
+	// the original was open
+	if err := store.MergeIssue(keep.ID, dup.ID); err != nil {
+		t.Fatalf("MergeIssue() failed: %v", err)
+	}
+	if keep.Status != "open" || keep.Resolution != "" || keep.DuplicateOf != "" {
+		t.Errorf("expected the kept issue to be reopened, got %+v", keep)
+	}
+
+	// A closed original passes on its resolution
+	dup, _ = store.AddIssue("Parser crashes again")
+	_ = store.CloseIssueAs(dup.ID, resolutionWontfix, "Old parser", "")
+	_ = store.CloseIssueAs(keep.ID, resolutionDuplicate, "", dup.ID)
+	if err := store.MergeIssue(keep.ID, dup.ID); err != nil {
+		t.Fatalf("MergeIssue() failed: %v", err)
+	}
+	if keep.Status != "closed" || keep.Resolution != resolutionWontfix || keep.Reason != "Old parser" || keep.DuplicateOf != "" {
+		t.Errorf("expected the kept issue to take the duplicate's resolution, got %+v", keep)
+	}
+}
+
+func TestStoreMergeIssue_Cycle(t *testing.T) {
+	store := NewStore()
+	keep, _ := store.AddIssue("Keep")
+	middle, _ := store.AddIssue("Middle")
+	dup, _ := store.AddIssue("Duplicate")
+	_ = store.AddDependency(middle.ID, keep.ID)
+	_ = store.AddDependency(dup.ID, middle.ID)
+
+	_ = store.AddComment(dup.ID, "Seen on Linux")
+
+	if err := store.MergeIssue(keep.ID, dup.ID); err == nil || !strings.Contains(err.Error(), "dependency cycle") {
+		t.Errorf("expected the cycle to be reported, got %v", err)
+	}
+
+	// The rejected merge leaves both issues as they were
+	if store.Issues[dup.ID] != dup || len(dup.DependsOn) != 1 || dup.DependsOn[0] != middle.ID {
+		t.Errorf("expected the duplicate to be intact, got %+v", dup)
+	}
+	if len(keep.Comments) != 0 || len(keep.DependsOn) != 0 || len(keep.Blocks) != 1 {
+		t.Errorf("expected the kept issue to be unchanged, got %+v", keep)
+	}
+	if len(middle.Blocks) != 1 || middle.Blocks[0] != dup.ID {
+		t.Errorf("expected middle to still block the duplicate, got %v", middle.Blocks)
+	}
+	if _, ok := store.Aliases[dup.ID]; ok {
+		t.Errorf("expected no alias for the duplicate, got %v", store.Aliases)
+	}
+}
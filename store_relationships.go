@@ -1,5 +1,7 @@
 package main
 
+import "slices"
+
 // AddDependency adds a dependency relationship (issue depends on dependsOnID)
 func (s *Store) AddDependency(issueID, dependsOnID string) error {
 	issue, err := s.GetIssue(issueID)
@@ -12,8 +14,8 @@ func (s *Store) AddDependency(issueID, dependsOnID string) error {
 		return err
 	}
 
-	issue.DependsOn = append(issue.DependsOn, dependsOnID)
-	blocker.Blocks = append(blocker.Blocks, issueID)
+	issue.DependsOn = append(issue.DependsOn, blocker.ID)
+	blocker.Blocks = append(blocker.Blocks, issue.ID)
 	s.touch(issue, blocker)
 	return nil
 }
@@ -30,8 +32,8 @@ func (s *Store) AddBlocker(issueID, blockedID string) error {
 		return err
 	}
 
-	issue.Blocks = append(issue.Blocks, blockedID)
-	blocked.DependsOn = append(blocked.DependsOn, issueID)
+	issue.Blocks = append(issue.Blocks, blocked.ID)
+	blocked.DependsOn = append(blocked.DependsOn, issue.ID)
 	s.touch(issue, blocked)
 	return nil
 }
@@ -51,7 +53,7 @@ func (s *Store) RemoveDependency(issueID, dependsOnID string) error {
 	// Remove dependsOnID from issue.DependsOn
 	newDeps := make([]string, 0, len(issue.DependsOn))
 	for _, depID := range issue.DependsOn {
-		if depID != dependsOnID {
+		if depID != blocker.ID {
 			newDeps = append(newDeps, depID)
 		}
 	}
@@ -60,7 +62,7 @@ func (s *Store) RemoveDependency(issueID, dependsOnID string) error {
 	// Remove issueID from blocker.Blocks
 	newBlocks := make([]string, 0, len(blocker.Blocks))
 	for _, blockID := range blocker.Blocks {
-		if blockID != issueID {
+		if blockID != issue.ID {
 			newBlocks = append(newBlocks, blockID)
 		}
 	}
@@ -85,7 +87,7 @@ func (s *Store) RemoveBlocker(issueID, blockedID string) error {
 	// Remove blockedID from issue.Blocks
 	newBlocks := make([]string, 0, len(issue.Blocks))
 	for _, blockID := range issue.Blocks {
-		if blockID != blockedID {
+		if blockID != blocked.ID {
 			newBlocks = append(newBlocks, blockID)
 		}
 	}
@@ -94,7 +96,7 @@ func (s *Store) RemoveBlocker(issueID, blockedID string) error {
 	// Remove issueID from blocked.DependsOn
 	newDeps := make([]string, 0, len(blocked.DependsOn))
 	for _, depID := range blocked.DependsOn {
-		if depID != issueID {
+		if depID != issue.ID {
 			newDeps = append(newDeps, depID)
 		}
 	}
@@ -103,3 +105,87 @@ func (s *Store) RemoveBlocker(issueID, blockedID string) error {
 	s.touch(issue, blocked)
 	return nil
 }
+
+// blockedDependents returns the open issues that depend on id and aren't
+// ready, the ones closing or deleting it can unblock
+func (s *Store) blockedDependents(id string) []*Issue {
+	issue := s.Issues[id]
+	if issue == nil {
+		return nil
+	}
+	var blocked []*Issue
+	for _, blockedID := range issue.Blocks {
+		if dependent := s.Issues[blockedID]; dependent != nil && dependent.Status == "open" && !s.IsReady(dependent) {
+			blocked = append(blocked, dependent)
+		}
+	}
+	return blocked
+}
+
+// nowReady returns the issues from blockedDependents that have since
+// become ready
+func (s *Store) nowReady(blocked []*Issue) []*Issue {
+	var ready []*Issue
+	for _, issue := range blocked {
+		if s.Issues[issue.ID] == issue && issue.Status == "open" && s.IsReady(issue) {
+			ready = append(ready, issue)
+		}
+	}
+	return ready
+}
+
+// dependencyGraph returns a copy of every issue's dependencies, keyed by ID,
+// that can be changed to check a planned edit for cycles
+func (s *Store) dependencyGraph() map[string][]string {
+	graph := make(map[string][]string, len(s.Issues))
+	for id, issue := range s.Issues {
+		graph[id] = append([]string(nil), issue.DependsOn...)
+	}
+	return graph
+}
+
+// findCycle returns the IDs along a dependency cycle in graph, starting and
+// ending with the same ID, or nil if there is none
+func findCycle(graph map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(graph))
+	var path []string
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		state[id] = visiting
+		path = append(path, id)
+		for _, depID := range graph[id] {
+			switch state[depID] {
+			case visiting:
+				start := slices.Index(path, depID)
+				return append(slices.Clone(path[start:]), depID)
+			case unvisited:
+				if cycle := visit(depID); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+		return nil
+	}
+
+	ids := make([]string, 0, len(graph))
+	for id := range graph {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		if state[id] == unvisited {
+			if cycle := visit(id); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
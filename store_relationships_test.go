@@ -1,6 +1,7 @@
 package main
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -39,6 +40,27 @@ func TestStoreRemoveDependency(t *testing.T) {
 	}
 }
 
+func TestStoreRelationships_ResolveAliases(t *testing.T) {
+	store := NewStore()
+	issue1, _ := store.AddIssue("Issue 1")
+	issue2, _ := store.AddIssue("Issue 2")
+	store.Aliases = map[string]string{"first": issue1.ID, "second": issue2.ID}
+
+	// Edges are recorded with full IDs, however the issues were named
+	if err := store.AddDependency("second", "first"); err != nil {
+		t.Fatalf("AddDependency() failed: %v", err)
+	}
+	if len(issue2.DependsOn) != 1 || issue2.DependsOn[0] != issue1.ID || len(issue1.Blocks) != 1 || issue1.Blocks[0] != issue2.ID {
+		t.Fatalf("expected full IDs, got depends on %v and blocks %v", issue2.DependsOn, issue1.Blocks)
+	}
+	if err := store.RemoveBlocker("first", "second"); err != nil {
+		t.Fatalf("RemoveBlocker() failed: %v", err)
+	}
+	if len(issue2.DependsOn) != 0 || len(issue1.Blocks) != 0 {
+		t.Errorf("expected the edge to be removed, got depends on %v and blocks %v", issue2.DependsOn, issue1.Blocks)
+	}
+}
+
 func TestStoreRemoveDependency_IssueNotFound(t *testing.T) {
 	store := NewStore()
 	issue, _ := store.AddIssue("Issue")
@@ -312,3 +334,20 @@ func TestStoreRemoveDependency_EmptyDependsOn(t *testing.T) {
 		t.Errorf("expected issue1 to have no blocks, got %d", len(issue1.Blocks))
 	}
 }
+
+func TestFindCycle(t *testing.T) {
+	graph := map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": nil,
+	}
+	if cycle := findCycle(graph); cycle != nil {
+		t.Errorf("expected no cycle, got %v", cycle)
+	}
+
+	graph["c"] = []string{"a"}
+	cycle := findCycle(graph)
+	if strings.Join(cycle, " ") != "a b c a" {
+		t.Errorf("expected cycle a b c a, got %v", cycle)
+	}
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// templateDir is where templates live, relative to the project root
+const templateDir = ".mint/templates"
+
+// templateVarPattern matches a {{name}} placeholder
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// Template describes an issue, and optionally its subtasks, created with
+// create --template. Any string can contain {{name}} placeholders.
+type Template struct {
+	Title       string            `yaml:"title"`
+	Description string            `yaml:"description"`
+	Labels      []string          `yaml:"labels"`
+	Priority    string            `yaml:"priority"`
+	Vars        map[string]string `yaml:"vars"` // defaults; empty means required
+	Subtasks    []TemplateSubtask `yaml:"subtasks"`
+}
+
+// TemplateSubtask is an issue the templated issue depends on
+// DependsOn lists the keys of other subtasks
+type TemplateSubtask struct {
+	Key         string   `yaml:"key"`
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description"`
+	Labels      []string `yaml:"labels"`
+	Priority    string   `yaml:"priority"`
+	DependsOn   []string `yaml:"depends_on"`
+}
+
+// LoadTemplate reads the template called name from the project at root
+func LoadTemplate(root, name string) (*Template, error) {
+	var tmpl Template
+	path, err := loadNamedYAML(root, templateDir, "template", name, &tmpl)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &tmpl, nil
+}
+
+// loadNamedYAML reads dir/name.yaml under root into out, rejecting unknown
+// fields. kind names the file in errors. Returns the path read.
+func loadNamedYAML(root, dir, kind, name string, out any) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid %s name %q", kind, name)
+	}
+
+	dir = filepath.Join(root, dir)
+	path := filepath.Join(dir, name+".yaml")
+	// #nosec G304 -- path is a file in one of the project's .mint directories
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		names, _ := listNamedYAML(dir)
+		if len(names) == 0 {
+			return "", fmt.Errorf("%s %q not found (no %ss in %s)", kind, name, kind, dir)
+		}
+		return "", fmt.Errorf("%s %q not found (available: %s)", kind, name, strings.Join(names, ", "))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := yaml.UnmarshalWithOptions(data, out, yaml.DisallowUnknownField()); err != nil {
+		return "", fmt.Errorf("%s: %s", path, yaml.FormatError(err, false, false))
+	}
+	return path, nil
+}
+
+// listNamedYAML returns the names of the .yaml files in dir
+func listNamedYAML(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".yaml"); ok && !entry.IsDir() {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// validate checks that subtask keys are unique and that their dependencies
+// name other subtasks without forming a cycle
+func (t *Template) validate() error {
+	graph := make(map[string][]string)
+	for i, sub := range t.Subtasks {
+		if sub.Title == "" {
+			return fmt.Errorf("subtask %d has no title", i+1)
+		}
+		if sub.Key == "" {
+			continue
+		}
+		if _, exists := graph[sub.Key]; exists {
+			return fmt.Errorf("duplicate subtask key %q", sub.Key)
+		}
+		graph[sub.Key] = sub.DependsOn
+	}
+	for _, sub := range t.Subtasks {
+		for _, key := range sub.DependsOn {
+			if _, exists := graph[key]; !exists {
+				return fmt.Errorf("subtask %q depends on unknown subtask %q", sub.Title, key)
+			}
+		}
+	}
+	if cycle := findCycle(graph); cycle != nil {
+		return fmt.Errorf("subtask dependency cycle: %s", strings.Join(cycle, " -> "))
+	}
+	return nil
+}
+
+// Expand returns a copy of the template with its placeholders replaced by
+// vars, falling back to the template's defaults
+func (t *Template) Expand(vars map[string]string) (*Template, error) {
+	e := newTemplateExpander(t.Vars, vars)
+	out := &Template{
+		Title:       e.expand(t.Title),
+		Description: e.expand(t.Description),
+		Labels:      e.expandAll(t.Labels),
+		Priority:    e.expand(t.Priority),
+	}
+	for _, sub := range t.Subtasks {
+		out.Subtasks = append(out.Subtasks, TemplateSubtask{
+			Key:         sub.Key,
+			Title:       e.expand(sub.Title),
+			Description: e.expand(sub.Description),
+			Labels:      e.expandAll(sub.Labels),
+			Priority:    e.expand(sub.Priority),
+			DependsOn:   sub.DependsOn,
+		})
+	}
+	if err := e.err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// templateExpander fills {{name}} placeholders, recording which variables
+// were used and which had no value
+type templateExpander struct {
+	values  map[string]string
+	vars    map[string]string
+	used    map[string]bool
+	missing []string
+}
+
+func newTemplateExpander(defaults, vars map[string]string) *templateExpander {
+	values := make(map[string]string)
+	for name, value := range defaults {
+		values[name] = value
+	}
+	for name, value := range vars {
+		values[name] = value
+	}
+	return &templateExpander{values: values, vars: vars, used: make(map[string]bool)}
+}
+
+func (e *templateExpander) expand(s string) string {
+	return templateVarPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := templateVarPattern.FindStringSubmatch(match)[1]
+		e.used[name] = true
+		value := e.values[name]
+		if value == "" && !slices.Contains(e.missing, name) {
+			e.missing = append(e.missing, name)
+		}
+		return value
+	})
+}
+
+func (e *templateExpander) expandAll(items []string) []string {
+	var out []string
+	for _, item := range items {
+		out = append(out, e.expand(item))
+	}
+	return out
+}
+
+// err reports placeholders without a value and vars that were never used,
+// so typos don't pass silently
+func (e *templateExpander) err() error {
+	if len(e.missing) > 0 {
+		slices.Sort(e.missing)
+		return fmt.Errorf("missing template variables: %s (set them with --var name=value)", strings.Join(e.missing, ", "))
+	}
+	for name := range e.vars {
+		if !e.used[name] {
+			return fmt.Errorf("template has no variable %q", name)
+		}
+	}
+	return nil
+}
+
+// parseTemplateVars parses name=value pairs from --var
+func parseTemplateVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid --var %q (expected name=value)", pair)
+		}
+		vars[strings.TrimSpace(name)] = value
+	}
+	return vars, nil
+}
+
+// templateLabels returns labels with priority recorded as a priority:<value>
+// label, since issues have no priority field
+func templateLabels(labels []string, priority string) []string {
+	var out []string
+	for _, label := range labels {
+		if label = strings.TrimSpace(label); label != "" && !slices.Contains(out, label) {
+			out = append(out, label)
+		}
+	}
+	if priority = strings.TrimSpace(priority); priority != "" {
+		out = append(out, "priority:"+priority)
+	}
+	return out
+}
+
+// CreateFromTemplate adds the issue described by an expanded template, and
+// its subtasks, to the store. The issue depends on every subtask, so it
+// becomes ready once they are all closed.
+// Returns the issue and its subtasks in template order.
+func (s *Store) CreateFromTemplate(tmpl *Template) (*Issue, []*Issue, error) {
+	if strings.TrimSpace(tmpl.Title) == "" {
+		return nil, nil, fmt.Errorf("title is required")
+	}
+
+	issue, err := s.AddIssue(tmpl.Title)
+	if err != nil {
+		return nil, nil, err
+	}
+	issue.Labels = templateLabels(tmpl.Labels, tmpl.Priority)
+	if tmpl.Description != "" {
+		if err := s.AddComment(issue.ID, strings.TrimSpace(tmpl.Description)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	subtasks := make([]*Issue, len(tmpl.Subtasks))
+	byKey := make(map[string]*Issue)
+	for i, sub := range tmpl.Subtasks {
+		subtask, err := s.AddIssue(sub.Title)
+		if err != nil {
+			return nil, nil, err
+		}
+		subtask.Labels = templateLabels(sub.Labels, sub.Priority)
+		if sub.Description != "" {
+			if err := s.AddComment(subtask.ID, strings.TrimSpace(sub.Description)); err != nil {
+				return nil, nil, err
+			}
+		}
+		if err := s.AddDependency(issue.ID, subtask.ID); err != nil {
+			return nil, nil, err
+		}
+		subtasks[i] = subtask
+		if sub.Key != "" {
+			byKey[sub.Key] = subtask
+		}
+	}
+
+	for i, sub := range tmpl.Subtasks {
+		for _, key := range sub.DependsOn {
+			if err := s.AddDependency(subtasks[i].ID, byKey[key].ID); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return issue, subtasks, nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const releaseTemplate = `title: "Release {{version}}"
+description: |
+  Ship {{ version }} to {{channel}}.
+labels: [release]
+priority: high
+vars:
+  channel: stable
+subtasks:
+  - key: changelog
+    title: "Update changelog for {{version}}"
+  - key: tag
+    title: "Tag v{{version}}"
+    depends_on: [changelog]
+  - title: "Announce {{version}}"
+    depends_on: [tag]
+`
+
+func writeTemplate(t *testing.T, root, name, content string) {
+	t.Helper()
+	dir := filepath.Join(root, templateDir)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadTemplate(t *testing.T) {
+	root := t.TempDir()
+	writeTemplate(t, root, "release", releaseTemplate)
+
+	tmpl, err := LoadTemplate(root, "release")
+	if err != nil {
+		t.Fatalf("LoadTemplate() failed: %v", err)
+	}
+	if len(tmpl.Subtasks) != 3 || tmpl.Vars["channel"] != "stable" {
+		t.Errorf("unexpected template: %+v", tmpl)
+	}
+
+	_, err = LoadTemplate(root, "bug")
+	if err == nil || !strings.Contains(err.Error(), "available: release") {
+		t.Errorf("expected not found error listing templates, got %v", err)
+	}
+	if _, err := LoadTemplate(root, "../release"); err == nil {
+		t.Error("expected error for a path in the template name")
+	}
+}
+
+func TestLoadTemplateValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown field", "title: x\nassignee: me\n", "unknown field"},
+		{"unknown subtask", "subtasks:\n  - title: a\n    depends_on: [b]\n", "unknown subtask"},
+		{"duplicate key", "subtasks:\n  - {key: a, title: a}\n  - {key: a, title: b}\n", "duplicate subtask key"},
+		{"cycle", "subtasks:\n  - {key: a, title: a, depends_on: [b]}\n  - {key: b, title: b, depends_on: [a]}\n", "cycle"},
+		{"no title", "subtasks:\n  - key: a\n", "has no title"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeTemplate(t, root, "bad", tt.content)
+			_, err := LoadTemplate(root, "bad")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestTemplateExpand(t *testing.T) {
+	root := t.TempDir()
+	writeTemplate(t, root, "release", releaseTemplate)
+	tmpl, _ := LoadTemplate(root, "release")
+
+	expanded, err := tmpl.Expand(map[string]string{"version": "1.2"})
+	if err != nil {
+		t.Fatalf("Expand() failed: %v", err)
+	}
+	if expanded.Title != "Release 1.2" {
+		t.Errorf("expected title 'Release 1.2', got %q", expanded.Title)
+	}
+	if expanded.Description != "Ship 1.2 to stable.\n" {
+		t.Errorf("expected default channel in description, got %q", expanded.Description)
+	}
+	if expanded.Subtasks[1].Title != "Tag v1.2" {
+		t.Errorf("expected subtask title 'Tag v1.2', got %q", expanded.Subtasks[1].Title)
+	}
+	if tmpl.Title != "Release {{version}}" {
+		t.Error("expected Expand to leave the template unchanged")
+	}
+
+	if _, err := tmpl.Expand(nil); err == nil || !strings.Contains(err.Error(), "missing template variables: version") {
+		t.Errorf("expected missing variable error, got %v", err)
+	}
+	if _, err := tmpl.Expand(map[string]string{"version": "1.2", "verison": "1.3"}); err == nil || !strings.Contains(err.Error(), `no variable "verison"`) {
+		t.Errorf("expected unused variable error, got %v", err)
+	}
+}
+
+func TestCreateFromTemplate(t *testing.T) {
+	root := t.TempDir()
+	writeTemplate(t, root, "release", releaseTemplate)
+	tmpl, _ := LoadTemplate(root, "release")
+	expanded, _ := tmpl.Expand(map[string]string{"version": "1.2"})
+
+	store := NewStore()
+	issue, subtasks, err := store.CreateFromTemplate(expanded)
+	if err != nil {
+		t.Fatalf("CreateFromTemplate() failed: %v", err)
+	}
+	if strings.Join(issue.Labels, ",") != "release,priority:high" {
+		t.Errorf("expected labels release,priority:high, got %v", issue.Labels)
+	}
+	if len(issue.Comments) != 1 || issue.Comments[0] != "Ship 1.2 to stable." {
+		t.Errorf("expected description comment, got %v", issue.Comments)
+	}
+	if len(subtasks) != 3 || len(issue.DependsOn) != 3 {
+		t.Fatalf("expected issue to depend on 3 subtasks, got %v", issue.DependsOn)
+	}
+	if len(subtasks[1].DependsOn) != 1 || subtasks[1].DependsOn[0] != subtasks[0].ID {
+		t.Errorf("expected tag to depend on changelog, got %v", subtasks[1].DependsOn)
+	}
+	if len(subtasks[2].DependsOn) != 1 || subtasks[2].DependsOn[0] != subtasks[1].ID {
+		t.Errorf("expected announce to depend on tag, got %v", subtasks[2].DependsOn)
+	}
+	if !store.IsReady(subtasks[0]) || store.IsReady(subtasks[1]) || store.IsReady(issue) {
+		t.Error("expected only the first subtask to be ready")
+	}
+}
+
+func TestParseTemplateVars(t *testing.T) {
+	vars, err := parseTemplateVars([]string{"version=1.2", "note=a=b"})
+	if err != nil {
+		t.Fatalf("parseTemplateVars() failed: %v", err)
+	}
+	if vars["version"] != "1.2" || vars["note"] != "a=b" {
+		t.Errorf("unexpected vars: %v", vars)
+	}
+	if _, err := parseTemplateVars([]string{"version"}); err == nil {
+		t.Error("expected error for a var without a value")
+	}
+}
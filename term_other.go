@@ -0,0 +1,22 @@
+//go:build !linux && !darwin
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+var errNoTerminal = errors.New("the terminal UI is only supported on Linux and macOS")
+
+func makeRaw(int) (func(), error) {
+	return nil, errNoTerminal
+}
+
+func terminalSize(int) (int, int, error) {
+	return 0, 0, errNoTerminal
+}
+
+func notifyResize() (<-chan os.Signal, func()) {
+	return make(chan os.Signal), func() {}
+}
@@ -0,0 +1,526 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// tuiMode is what key presses currently control
+type tuiMode int
+
+const (
+	tuiBrowse tuiMode = iota // moving between issues
+	tuiInput                 // typing a comment or title
+	tuiPick                  // choosing an issue from a fuzzy list
+)
+
+const tuiHelp = "j/k move  tab pane  n new  c comment  x close  o reopen  d add dep  D remove dep  q quit"
+
+// tui is the state of the terminal UI, kept separate from the terminal so
+// key handling and rendering can be tested
+type tui struct {
+	store *Store
+	save  func() error
+
+	sections []issueSection
+	pane     int
+	cursors  []int
+
+	mode     tuiMode
+	prompt   string
+	input    []rune
+	onSubmit func(text string)
+	picker   tuiPicker
+
+	message string
+	quit    bool
+}
+
+// tuiPicker is a fuzzy-filtered list of issues to choose from
+type tuiPicker struct {
+	title      string
+	candidates []*Issue
+	matches    []*Issue
+	cursor     int
+	onPick     func(issue *Issue)
+}
+
+// newTUI creates a UI over store; save is called after every change
+func newTUI(store *Store, save func() error) *tui {
+	t := &tui{store: store, save: save}
+	t.refresh("")
+	return t
+}
+
+// refresh re-partitions the issues, moving the cursor to selectID if given
+func (t *tui) refresh(selectID string) {
+	t.sections = issueSections(t.store)
+	if t.cursors == nil {
+		t.cursors = make([]int, len(t.sections))
+	}
+	for i, section := range t.sections {
+		if selectID != "" {
+			if j := slices.IndexFunc(section.Issues, func(issue *Issue) bool { return issue.ID == selectID }); j >= 0 {
+				t.pane, t.cursors[i] = i, j
+			}
+		}
+		t.cursors[i] = max(0, min(t.cursors[i], len(section.Issues)-1))
+	}
+}
+
+// selected returns the issue under the cursor, or nil if the pane is empty
+func (t *tui) selected() *Issue {
+	issues := t.sections[t.pane].Issues
+	if len(issues) == 0 {
+		return nil
+	}
+	return issues[t.cursors[t.pane]]
+}
+
+// commit saves the result of a change and keeps selectID under the cursor,
+// or shows the error if the change failed
+func (t *tui) commit(selectID, message string, err error) {
+	if err == nil {
+		err = t.save()
+	}
+	if err != nil {
+		t.message = err.Error()
+		t.refresh(selectID)
+		return
+	}
+	t.message = message
+	t.refresh(selectID)
+}
+
+// handleKey applies a key press as returned by parseKeys
+func (t *tui) handleKey(key string) {
+	switch t.mode {
+	case tuiInput:
+		t.handleInputKey(key)
+	case tuiPick:
+		t.handlePickKey(key)
+	default:
+		t.handleBrowseKey(key)
+	}
+}
+
+func (t *tui) handleBrowseKey(key string) {
+	t.message = ""
+	issue := t.selected()
+	switch key {
+	case "q", "ctrl+c":
+		t.quit = true
+	case "j", "down":
+		t.cursors[t.pane] = min(t.cursors[t.pane]+1, max(0, len(t.sections[t.pane].Issues)-1))
+	case "k", "up":
+		t.cursors[t.pane] = max(t.cursors[t.pane]-1, 0)
+	case "tab", "l", "right":
+		t.pane = (t.pane + 1) % len(t.sections)
+	case "shift+tab", "h", "left":
+		t.pane = (t.pane + len(t.sections) - 1) % len(t.sections)
+	case "n":
+		t.startInput("New issue title: ", func(title string) {
+			created, err := t.store.AddIssue(title)
+			if err != nil {
+				t.message = err.Error()
+				return
+			}
+			t.commit(created.ID, "Created "+created.ID, nil)
+		})
+	case "c":
+		if issue == nil {
+			return
+		}
+		t.startInput("Comment on "+issue.ID+": ", func(comment string) {
+			t.commit(issue.ID, "Commented on "+issue.ID, t.store.AddComment(issue.ID, comment))
+		})
+	case "x":
+		if issue == nil {
+			return
+		}
+		if issue.Status == "closed" {
+			t.message = issue.ID + " is already closed"
+			return
+		}
+		_, err := t.store.CloseIssues(issue.ID, resolutionDone, "", "", false, false)
+		t.commit(issue.ID, "Closed "+issue.ID, err)
+	case "o":
+		if issue == nil {
+			return
+		}
+		if issue.Status == "open" {
+			t.message = issue.ID + " is already open"
+			return
+		}
+		t.commit(issue.ID, "Reopened "+issue.ID, t.store.ReopenIssue(issue.ID))
+	case "d":
+		if issue == nil {
+			return
+		}
+		var candidates []*Issue
+		for _, other := range t.store.ListIssues() {
+			if other.ID != issue.ID && !slices.Contains(issue.DependsOn, other.ID) {
+				candidates = append(candidates, other)
+			}
+		}
+		t.startPick(issue.ID+" depends on", candidates, func(dep *Issue) {
+			t.commit(issue.ID, issue.ID+" now depends on "+dep.ID, t.store.AddDependency(issue.ID, dep.ID))
+		})
+	case "D":
+		if issue == nil {
+			return
+		}
+		var candidates []*Issue
+		for _, depID := range issue.DependsOn {
+			if dep := t.store.Issues[depID]; dep != nil {
+				candidates = append(candidates, dep)
+			}
+		}
+		if len(candidates) == 0 {
+			t.message = issue.ID + " has no dependencies"
+			return
+		}
+		t.startPick(issue.ID+" no longer depends on", candidates, func(dep *Issue) {
+			t.commit(issue.ID, issue.ID+" no longer depends on "+dep.ID, t.store.RemoveDependency(issue.ID, dep.ID))
+		})
+	}
+}
+
+func (t *tui) startInput(prompt string, onSubmit func(text string)) {
+	t.mode = tuiInput
+	t.prompt = prompt
+	t.input = nil
+	t.onSubmit = onSubmit
+}
+
+func (t *tui) handleInputKey(key string) {
+	switch key {
+	case "esc", "ctrl+c":
+		t.mode = tuiBrowse
+	case "enter":
+		text := strings.TrimSpace(string(t.input))
+		if text == "" {
+			return
+		}
+		t.mode = tuiBrowse
+		t.onSubmit(text)
+	case "backspace":
+		if len(t.input) > 0 {
+			t.input = t.input[:len(t.input)-1]
+		}
+	default:
+		if utf8.RuneCountInString(key) == 1 {
+			t.input = append(t.input, []rune(key)...)
+		}
+	}
+}
+
+func (t *tui) startPick(title string, candidates []*Issue, onPick func(issue *Issue)) {
+	t.mode = tuiPick
+	t.input = nil
+	t.picker = tuiPicker{title: title, candidates: candidates, onPick: onPick}
+	t.filterPicker()
+}
+
+// filterPicker keeps the candidates matching the typed query, best first
+func (t *tui) filterPicker() {
+	query := string(t.input)
+	type match struct {
+		issue *Issue
+		score int
+	}
+	var matches []match
+	for _, issue := range t.picker.candidates {
+		if score, ok := fuzzyMatch(query, issue.ID+" "+issue.Title); ok {
+			matches = append(matches, match{issue, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	t.picker.matches = t.picker.matches[:0]
+	for _, m := range matches {
+		t.picker.matches = append(t.picker.matches, m.issue)
+	}
+	t.picker.cursor = 0
+}
+
+func (t *tui) handlePickKey(key string) {
+	switch key {
+	case "esc", "ctrl+c":
+		t.mode = tuiBrowse
+	case "down", "ctrl+n", "tab":
+		t.picker.cursor = min(t.picker.cursor+1, max(0, len(t.picker.matches)-1))
+	case "up", "ctrl+p", "shift+tab":
+		t.picker.cursor = max(t.picker.cursor-1, 0)
+	case "enter":
+		if len(t.picker.matches) == 0 {
+			return
+		}
+		t.mode = tuiBrowse
+		t.picker.onPick(t.picker.matches[t.picker.cursor])
+	case "backspace":
+		if len(t.input) > 0 {
+			t.input = t.input[:len(t.input)-1]
+			t.filterPicker()
+		}
+	default:
+		if utf8.RuneCountInString(key) == 1 {
+			t.input = append(t.input, []rune(key)...)
+			t.filterPicker()
+		}
+	}
+}
+
+// fuzzyMatch reports whether every character of query appears in text in
+// order, ignoring case. Higher scores mean more of the query was found in
+// consecutive runs, earlier in the text.
+func fuzzyMatch(query, text string) (int, bool) {
+	query, text = strings.ToLower(query), strings.ToLower(text)
+	score, pos, prev := 0, 0, -2
+	for _, r := range query {
+		i := strings.IndexRune(text[pos:], r)
+		if i < 0 {
+			return 0, false
+		}
+		i += pos
+		if i == prev+1 {
+			score += 3
+		}
+		score -= i - pos
+		prev = i
+		pos = i + utf8.RuneLen(r)
+	}
+	return score, true
+}
+
+// view renders the screen for a terminal of the given size
+func (t *tui) view(width, height int) string {
+	width, height = max(width, 20), max(height, 6)
+	bodyHeight := height - 3
+	leftWidth := min(max(width*2/5, 30), width-10)
+	rightWidth := width - leftWidth - 3
+
+	left := t.viewSections(leftWidth, bodyHeight)
+	var right []string
+	if t.mode == tuiPick {
+		right = t.viewPicker(bodyHeight)
+	} else {
+		right = t.viewDetails()
+	}
+
+	var lines []string
+	counts := make([]string, len(t.sections))
+	for i, section := range t.sections {
+		counts[i] = fmt.Sprintf("%s %d", strings.ToUpper(section.Title), len(section.Issues))
+	}
+	lines = append(lines, truncateANSI("\033[1mmint\033[0m  \033[38;5;8m"+strings.Join(counts, "  ")+"\033[0m", width))
+	for i := range bodyHeight {
+		line := padANSI(left[i], leftWidth) + " \033[38;5;8m│\033[0m "
+		if i < len(right) {
+			line += truncateANSI(right[i], rightWidth)
+		}
+		lines = append(lines, line)
+	}
+
+	switch {
+	case t.mode == tuiInput:
+		lines = append(lines, "", truncateANSI("\033[1m"+t.prompt+"\033[0m"+string(t.input)+"\033[7m \033[0m", width))
+	case t.message != "":
+		lines = append(lines, truncateANSI(t.message, width), truncateANSI("\033[38;5;8m"+tuiHelp+"\033[0m", width))
+	default:
+		lines = append(lines, "", truncateANSI("\033[38;5;8m"+tuiHelp+"\033[0m", width))
+	}
+
+	var b strings.Builder
+	b.WriteString("\033[H")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+		b.WriteString("\033[K")
+	}
+	b.WriteString("\033[J")
+	return b.String()
+}
+
+// viewSections renders the Ready, Blocked, and Closed panes stacked in a
+// column, scrolling each so its cursor stays visible
+func (t *tui) viewSections(width, height int) []string {
+	var lines []string
+	for i, section := range t.sections {
+		paneHeight := height / len(t.sections)
+		if i == len(t.sections)-1 {
+			paneHeight = height - len(lines)
+		}
+
+		header := fmt.Sprintf(" %s (%d) ", strings.ToUpper(section.Title), len(section.Issues))
+		if i == t.pane {
+			header = "\033[1m\033[7m\033[38;5;5m" + header + "\033[0m"
+		} else {
+			header = "\033[1m\033[38;5;5m" + header + "\033[0m"
+		}
+		pane := []string{header}
+
+		rows := paneHeight - 1
+		cursor := t.cursors[i]
+		offset := max(0, cursor-rows+1)
+		for j := offset; j < len(section.Issues) && j < offset+rows; j++ {
+			issue := section.Issues[j]
+			if i == t.pane && j == cursor {
+				pane = append(pane, "\033[7m"+padANSI(" "+issue.ID+" "+issue.Title, width)+"\033[0m")
+			} else {
+				pane = append(pane, " "+t.store.FormatID(issue.ID)+" "+issue.Title)
+			}
+		}
+		if len(section.Issues) == 0 {
+			pane = append(pane, "\033[38;5;8m No "+strings.ToLower(section.Title)+" issues\033[0m")
+		}
+
+		for len(pane) < paneHeight {
+			pane = append(pane, "")
+		}
+		lines = append(lines, pane[:paneHeight]...)
+	}
+	return lines
+}
+
+// viewDetails renders the selected issue the way show does
+func (t *tui) viewDetails() []string {
+	issue := t.selected()
+	if issue == nil {
+		return []string{"", "\033[38;5;8mNo issue selected\033[0m"}
+	}
+	var buf bytes.Buffer
+	if err := PrintIssueDetails(&buf, issue, t.store); err != nil {
+		return []string{err.Error()}
+	}
+	return strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+}
+
+// viewPicker renders the fuzzy list with the query being typed
+func (t *tui) viewPicker(height int) []string {
+	lines := []string{
+		"",
+		"\033[1m\033[38;5;5m" + t.picker.title + "\033[0m " + string(t.input) + "\033[7m \033[0m",
+		"",
+	}
+	rows := height - len(lines)
+	offset := max(0, t.picker.cursor-rows+1)
+	for i := offset; i < len(t.picker.matches) && i < offset+rows; i++ {
+		issue := t.picker.matches[i]
+		line := issue.ID + " " + issue.Status + " " + issue.Title
+		if i == t.picker.cursor {
+			line = "\033[7m" + line + "\033[0m"
+		}
+		lines = append(lines, line)
+	}
+	if len(t.picker.matches) == 0 {
+		lines = append(lines, "\033[38;5;8mNo matching issues\033[0m")
+	}
+	return lines
+}
+
+// visibleLen returns the number of runes in s that aren't part of an escape
+// sequence
+func visibleLen(s string) int {
+	return utf8.RuneCountInString(ansiPattern.ReplaceAllString(s, ""))
+}
+
+// truncateANSI cuts s to width visible runes, keeping escape sequences intact
+func truncateANSI(s string, width int) string {
+	if visibleLen(s) <= width {
+		return s
+	}
+	var b strings.Builder
+	visible := 0
+	for i := 0; i < len(s); {
+		if loc := ansiPattern.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+			b.WriteString(s[i : i+loc[1]])
+			i += loc[1]
+			continue
+		}
+		if visible == width {
+			break
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		b.WriteRune(r)
+		visible++
+		i += size
+	}
+	b.WriteString("\033[0m")
+	return b.String()
+}
+
+// padANSI truncates or pads s with spaces to exactly width visible runes
+func padANSI(s string, width int) string {
+	s = truncateANSI(s, width)
+	return s + strings.Repeat(" ", max(0, width-visibleLen(s)))
+}
+
+// parseKeys splits raw terminal input into key names like "up", "enter",
+// "ctrl+c", or a single character
+func parseKeys(b []byte) []string {
+	var keys []string
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b && len(b) > 2 && (b[1] == '[' || b[1] == 'O'):
+			// CSI or SS3 sequence: parameters end at a byte in 0x40-0x7e
+			end := 2
+			for end < len(b) && (b[end] < 0x40 || b[end] > 0x7e) {
+				end++
+			}
+			if end == len(b) {
+				return append(keys, "esc")
+			}
+			switch string(b[2 : end+1]) {
+			case "A":
+				keys = append(keys, "up")
+			case "B":
+				keys = append(keys, "down")
+			case "C":
+				keys = append(keys, "right")
+			case "D":
+				keys = append(keys, "left")
+			case "Z":
+				keys = append(keys, "shift+tab")
+			case "3~":
+				keys = append(keys, "delete")
+			}
+			b = b[end+1:]
+		case c == 0x1b:
+			keys = append(keys, "esc")
+			b = b[1:]
+		case c == 3:
+			keys = append(keys, "ctrl+c")
+			b = b[1:]
+		case c == '\r' || c == '\n':
+			keys = append(keys, "enter")
+			b = b[1:]
+		case c == 127 || c == 8:
+			keys = append(keys, "backspace")
+			b = b[1:]
+		case c == '\t':
+			keys = append(keys, "tab")
+			b = b[1:]
+		case c == 14:
+			keys = append(keys, "ctrl+n")
+			b = b[1:]
+		case c == 16:
+			keys = append(keys, "ctrl+p")
+			b = b[1:]
+		case c < 32:
+			b = b[1:]
+		default:
+			r, size := utf8.DecodeRune(b)
+			if r != utf8.RuneError {
+				keys = append(keys, string(r))
+			}
+			b = b[size:]
+		}
+	}
+	return keys
+}
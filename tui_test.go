@@ -0,0 +1,254 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newTestTUI creates a UI over store that counts saves
+func newTestTUI(store *Store) (*tui, *int) {
+	saves := 0
+	return newTUI(store, func() error {
+		saves++
+		return nil
+	}), &saves
+}
+
+// typeKeys sends key names as returned by parseKeys
+func typeKeys(ui *tui, keys ...string) {
+	for _, key := range keys {
+		ui.handleKey(key)
+	}
+}
+
+// typeText sends each character of text as a key
+func typeText(ui *tui, text string) {
+	for _, r := range text {
+		ui.handleKey(string(r))
+	}
+}
+
+func TestTUI_Navigation(t *testing.T) {
+	store := NewStore()
+	first, _ := store.AddIssue("First")
+	_, _ = store.AddIssue("Second")
+	third, _ := store.AddIssue("Third")
+	_ = store.AddDependency(third.ID, first.ID)
+
+	ui, _ := newTestTUI(store)
+	ready := ui.sections[0].Issues
+	if len(ready) != 2 || len(ui.sections[1].Issues) != 1 {
+		t.Fatalf("expected 2 ready and 1 blocked issue, got %d and %d", len(ready), len(ui.sections[1].Issues))
+	}
+
+	typeKeys(ui, "j")
+	if ui.selected() != ready[1] {
+		t.Errorf("expected j to move down to %s, got %s", ready[1].ID, ui.selected().ID)
+	}
+	typeKeys(ui, "j", "j")
+	if ui.selected() != ready[1] {
+		t.Error("expected cursor to stop at the last issue")
+	}
+	typeKeys(ui, "up")
+	if ui.selected() != ready[0] {
+		t.Error("expected up to move back")
+	}
+
+	typeKeys(ui, "tab")
+	if ui.selected() != third {
+		t.Errorf("expected tab to focus the blocked pane, got %v", ui.selected())
+	}
+	typeKeys(ui, "tab")
+	if ui.selected() != nil {
+		t.Error("expected empty closed pane to have no selection")
+	}
+	typeKeys(ui, "shift+tab", "shift+tab")
+	if ui.pane != 0 {
+		t.Errorf("expected shift+tab to return to the ready pane, got pane %d", ui.pane)
+	}
+
+	typeKeys(ui, "q")
+	if !ui.quit {
+		t.Error("expected q to quit")
+	}
+}
+
+func TestTUI_CloseReopenAndComment(t *testing.T) {
+	store := NewStore()
+	issue, _ := store.AddIssue("Only issue")
+	ui, saves := newTestTUI(store)
+
+	typeKeys(ui, "x")
+	if issue.Status != "closed" || *saves != 1 {
+		t.Fatalf("expected issue closed and saved, got %s with %d saves", issue.Status, *saves)
+	}
+	if ui.pane != 2 || ui.selected() != issue {
+		t.Error("expected cursor to follow the issue into the closed pane")
+	}
+	if ui.message != "Closed "+issue.ID {
+		t.Errorf("unexpected message %q", ui.message)
+	}
+
+	typeKeys(ui, "x")
+	if !strings.Contains(ui.message, "already closed") || *saves != 1 {
+		t.Errorf("expected closing twice to do nothing, got %q", ui.message)
+	}
+
+	typeKeys(ui, "o")
+	if issue.Status != "open" || ui.pane != 0 {
+		t.Errorf("expected issue reopened in the ready pane, got %s in pane %d", issue.Status, ui.pane)
+	}
+
+	typeKeys(ui, "c")
+	typeText(ui, "Needs a tesx")
+	typeKeys(ui, "backspace", "t", "enter")
+	if len(issue.Comments) != 1 || issue.Comments[0] != "Needs a test" {
+		t.Errorf("expected comment to be added, got %v", issue.Comments)
+	}
+	if ui.mode != tuiBrowse {
+		t.Error("expected enter to return to browsing")
+	}
+
+	// Escape cancels without changing anything
+	typeKeys(ui, "c")
+	typeText(ui, "never mind")
+	typeKeys(ui, "esc")
+	if len(issue.Comments) != 1 || ui.mode != tuiBrowse {
+		t.Errorf("expected esc to cancel the comment, got %v", issue.Comments)
+	}
+}
+
+func TestTUI_CreateIssue(t *testing.T) {
+	store := NewStore()
+	ui, saves := newTestTUI(store)
+
+	typeKeys(ui, "n", "enter")
+	if ui.mode != tuiInput || len(store.Issues) != 0 {
+		t.Fatal("expected an empty title to be ignored")
+	}
+	typeText(ui, "Brand new issue")
+	typeKeys(ui, "enter")
+
+	if len(store.Issues) != 1 || *saves != 1 {
+		t.Fatalf("expected 1 issue and 1 save, got %d and %d", len(store.Issues), *saves)
+	}
+	if selected := ui.selected(); selected == nil || selected.Title != "Brand new issue" {
+		t.Errorf("expected the new issue to be selected, got %v", selected)
+	}
+}
+
+func TestTUI_Dependencies(t *testing.T) {
+	store := NewStore()
+	app, _ := store.AddIssue("Build the app")
+	db, _ := store.AddIssue("Set up database")
+	_, _ = store.AddIssue("Write docs")
+	ui, _ := newTestTUI(store)
+	ui.refresh(app.ID)
+
+	typeKeys(ui, "d")
+	if ui.mode != tuiPick || len(ui.picker.matches) != 2 {
+		t.Fatalf("expected a picker with the 2 other issues, got %d", len(ui.picker.matches))
+	}
+	typeText(ui, "dtbs")
+	if len(ui.picker.matches) != 1 || ui.picker.matches[0] != db {
+		t.Fatalf("expected fuzzy query to match only the database issue, got %v", ui.picker.matches)
+	}
+	typeKeys(ui, "enter")
+	if !slices.Contains(app.DependsOn, db.ID) || !slices.Contains(db.Blocks, app.ID) {
+		t.Fatalf("expected %s to depend on %s", app.ID, db.ID)
+	}
+	if ui.pane != 1 || ui.selected() != app {
+		t.Error("expected cursor to follow the issue into the blocked pane")
+	}
+
+	typeKeys(ui, "D", "enter")
+	if len(app.DependsOn) != 0 || len(db.Blocks) != 0 {
+		t.Errorf("expected dependency to be removed, got %v and %v", app.DependsOn, db.Blocks)
+	}
+
+	typeKeys(ui, "D")
+	if ui.mode != tuiBrowse || !strings.Contains(ui.message, "no dependencies") {
+		t.Errorf("expected no picker without dependencies, got %q", ui.message)
+	}
+}
+
+func TestTUI_View(t *testing.T) {
+	store := NewStore()
+	issue, _ := store.AddIssue("A rather long issue title that will not fit in the left pane")
+	_ = store.AddComment(issue.ID, "Some details")
+	ui, _ := newTestTUI(store)
+
+	screen := ui.view(60, 12)
+	lines := strings.Split(screen, "\r\n")
+	if len(lines) != 12 {
+		t.Errorf("expected 12 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		line = strings.NewReplacer("\x1b[K", "", "\x1b[J", "", "\x1b[H", "").Replace(line)
+		if n := visibleLen(line); n > 60 {
+			t.Errorf("line %d is %d columns wide: %q", i, n, stripANSI(line))
+		}
+	}
+
+	plain := stripANSI(screen)
+	for _, want := range []string{"READY (1)", "BLOCKED (0)", "CLOSED (0)", "Title   A rather", "j/k move"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("expected screen to contain %q, got:\n%s", want, plain)
+		}
+	}
+
+	typeKeys(ui, "c")
+	typeText(ui, "hi")
+	if plain := stripANSI(ui.view(60, 12)); !strings.Contains(plain, "Comment on "+issue.ID+": hi") {
+		t.Errorf("expected comment prompt, got:\n%s", plain)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"jk", []string{"j", "k"}},
+		{"\x1b[A\x1b[B\x1bOC\x1b[D", []string{"up", "down", "right", "left"}},
+		{"\x1b", []string{"esc"}},
+		{"\x1b[Z\t", []string{"shift+tab", "tab"}},
+		{"\r\x7f\x03", []string{"enter", "backspace", "ctrl+c"}},
+		{"é", []string{"é"}},
+		{"\x1b[3~", []string{"delete"}},
+	}
+	for _, tt := range tests {
+		got := parseKeys([]byte(tt.input))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("parseKeys(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	if _, ok := fuzzyMatch("dbs", "Set up database"); !ok {
+		t.Error("expected subsequence to match")
+	}
+	if _, ok := fuzzyMatch("xyz", "Set up database"); ok {
+		t.Error("expected missing characters not to match")
+	}
+	exact, _ := fuzzyMatch("data", "Set up database")
+	scattered, _ := fuzzyMatch("data", "Deploy a new tracker app")
+	if exact <= scattered {
+		t.Errorf("expected consecutive match to score higher, got %d and %d", exact, scattered)
+	}
+}
+
+func TestTruncateANSI(t *testing.T) {
+	s := "\x1b[1mbold\x1b[0m text"
+	if got := truncateANSI(s, 6); stripANSI(got) != "bold t" {
+		t.Errorf("expected visible text to be cut to 6 runes, got %q", got)
+	}
+	if got := truncateANSI(s, 20); got != s {
+		t.Errorf("expected short string unchanged, got %q", got)
+	}
+	if got := padANSI("ab", 4); got != "ab  " {
+		t.Errorf("expected padding, got %q", got)
+	}
+}
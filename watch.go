@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+)
+
+// watchPollInterval is how often a file is checked for changes when the
+// platform can't report them
+var watchPollInterval = 500 * time.Millisecond
+
+// watchRenderer draws one frame of a watched view, marking the issues in
+// changed
+type watchRenderer func(w io.Writer, store *Store, changed map[string]bool) error
+
+// watchStore draws render's output, then redraws it whenever the store at
+// filePath changes, until interrupted. Each frame marks the issues whose
+// status or relationships changed since the frame before.
+func watchStore(ctx context.Context, w io.Writer, filePath string, render watchRenderer) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	events := watchFile(ctx, filePath)
+	var last []byte
+	var previous map[string]issueState
+	for first := true; ; first = false {
+		// Events can arrive for saves that changed nothing
+		data, _ := os.ReadFile(filePath)
+		if first || !bytes.Equal(data, last) {
+			last = data
+
+			var frame bytes.Buffer
+			frame.WriteString("\033[H\033[2J")
+			store, err := LoadStore(filePath)
+			if err == nil {
+				next := snapshotIssues(store)
+				err = render(&frame, store, changedIssues(previous, next))
+				previous = next
+			}
+			if err != nil {
+				fmt.Fprintf(&frame, "\n%v\n\n", err)
+			}
+			fmt.Fprintf(&frame, "\033[38;5;8mWatching %s (updated %s). Press Ctrl-C to stop.\033[0m\n", filePath, time.Now().Format(time.TimeOnly))
+			if _, err := w.Write(frame.Bytes()); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-events:
+		}
+	}
+}
+
+// issueState is the part of an issue watch compares between frames
+type issueState struct {
+	status    string
+	dependsOn string
+	blocks    string
+}
+
+func snapshotIssues(store *Store) map[string]issueState {
+	states := make(map[string]issueState, len(store.Issues))
+	for id, issue := range store.Issues {
+		states[id] = issueState{
+			status:    issue.Status,
+			dependsOn: strings.Join(slices.Sorted(slices.Values(issue.DependsOn)), ","),
+			blocks:    strings.Join(slices.Sorted(slices.Values(issue.Blocks)), ","),
+		}
+	}
+	return states
+}
+
+// changedIssues returns the IDs of issues that are new in next or whose
+// state differs from previous. Nothing has changed before the first frame.
+func changedIssues(previous, next map[string]issueState) map[string]bool {
+	if previous == nil {
+		return nil
+	}
+	changed := make(map[string]bool)
+	for id, state := range next {
+		if old, ok := previous[id]; !ok || old != state {
+			changed[id] = true
+		}
+	}
+	return changed
+}
+
+// watchFile sends on the returned channel when the file at path may have
+// changed, until ctx is done. It uses the platform's file notifications
+// when it can, and polls otherwise.
+func watchFile(ctx context.Context, path string) <-chan struct{} {
+	if events, err := notifyFileChanges(ctx, path); err == nil {
+		return events
+	}
+	return pollFile(ctx, path, watchPollInterval)
+}
+
+// pollFile checks the file at path every interval, sending when it was
+// replaced, resized, or modified
+func pollFile(ctx context.Context, path string, interval time.Duration) <-chan struct{} {
+	events := make(chan struct{}, 1)
+	last := statOrNil(path)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if info := statOrNil(path); !sameFileInfo(last, info) {
+				last = info
+				notifyChange(events)
+			}
+		}
+	}()
+	return events
+}
+
+func statOrNil(path string) os.FileInfo {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	return info
+}
+
+// notifyChange sends on events without blocking; a pending event already
+// covers this change
+func notifyChange(events chan<- struct{}) {
+	select {
+	case events <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package main
+
+import (
+	"context"
+	"errors"
+)
+
+// notifyFileChanges isn't supported here, so watching falls back to polling
+func notifyFileChanges(context.Context, string) (<-chan struct{}, error) {
+	return nil, errors.New("file notifications are only supported on Linux")
+}
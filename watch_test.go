@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lockedBuffer is a buffer that can be written and read from different
+// goroutines
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// waitFor fails the test unless cond becomes true within a few seconds
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestChangedIssues(t *testing.T) {
+	store := NewStore()
+	a, _ := store.AddIssue("A")
+	b, _ := store.AddIssue("B")
+	c, _ := store.AddIssue("C")
+	before := snapshotIssues(store)
+
+	if changed := changedIssues(nil, before); len(changed) != 0 {
+		t.Errorf("expected nothing changed in the first frame, got %v", changed)
+	}
+
+	_ = store.AddDependency(b.ID, a.ID)
+	_ = store.UpdateIssueTitle(c.ID, "Renamed")
+	d, _ := store.AddIssue("D")
+	changed := changedIssues(before, snapshotIssues(store))
+	for id, want := range map[string]bool{a.ID: true, b.ID: true, c.ID: false, d.ID: true} {
+		if changed[id] != want {
+			t.Errorf("changed[%s] = %v, want %v", id, changed[id], want)
+		}
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	watchers := map[string]func(ctx context.Context, path string) <-chan struct{}{
+		"default": watchFile,
+		"polling": func(ctx context.Context, path string) <-chan struct{} {
+			return pollFile(ctx, path, 10*time.Millisecond)
+		},
+	}
+	for name, watch := range watchers {
+		t.Run(name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "mint-issues.yaml")
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events := watch(ctx, filePath)
+
+			store := NewStore()
+			if _, err := store.AddIssue("A"); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Save(filePath); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case <-events:
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected an event after saving")
+			}
+		})
+	}
+}
+
+func TestWatchStore(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "mint-issues.yaml")
+	store := NewStore()
+	a, _ := store.AddIssue("Write parser")
+	b, _ := store.AddIssue("Write docs")
+	if err := store.Save(filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out lockedBuffer
+	done := make(chan error)
+	go func() {
+		done <- watchStore(ctx, &out, filePath, func(w io.Writer, store *Store, changed map[string]bool) error {
+			return printList(w, store, listOptions{}, changed)
+		})
+	}()
+	waitFor(t, "the first frame", func() bool { return strings.Contains(out.String(), "Write docs") })
+	if strings.Contains(out.String(), "← changed") {
+		t.Errorf("expected no changes marked in the first frame")
+	}
+
+	store, _ = LoadStore(filePath)
+	_ = store.CloseIssue(a.ID, "")
+	if err := store.Save(filePath); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "a redraw", func() bool { return strings.Contains(out.String(), "← changed") })
+
+	frames := strings.Split(stripANSI(out.String()), "\033[H\033[2J")
+	last := frames[len(frames)-1]
+	if !strings.Contains(last, "closed Write parser ← changed") {
+		t.Errorf("expected the closed issue to be marked, got:\n%s", last)
+	}
+	if strings.Contains(last, "Write docs ← changed") {
+		t.Errorf("expected %s to be unmarked, got:\n%s", b.ID, last)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("watchStore returned %v", err)
+	}
+}